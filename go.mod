@@ -4,6 +4,7 @@ go 1.22
 
 require (
 	github.com/spf13/cobra v1.8.1
+	github.com/ulikunitz/xz v0.5.17
 	gopkg.in/yaml.v3 v3.0.1
 )
 
@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cuongtl1992/vibe-skills/internal/registry"
+	"github.com/spf13/cobra"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect the local registry cache",
+	Long: `Inspect the registry index cache stored in ~/.vibe-skills/cache.
+
+Examples:
+  vibe-skills cache stats         # Show cached refs, their age and size
+  vibe-skills cache clear         # Remove every cached ref
+  vibe-skills cache clear develop # Remove the cached index for one ref
+  vibe-skills cache warm v1.0.0   # Fetch an index now so later --offline runs can use it`,
+}
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show cached refs with their age, validity and size",
+	Args:  cobra.NoArgs,
+	RunE:  runCacheStats,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear [ref]",
+	Short: "Remove cached registry data, for all refs or a single ref",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runCacheClear,
+}
+
+var cacheWarmCmd = &cobra.Command{
+	Use:   "warm [ref]",
+	Short: "Fetch the registry index for a ref into the cache",
+	Long: `Fetch the registry index for a ref, or the resolved ref when none is given,
+and store it in the cache. Later commands, including ones run with --offline,
+use the cached copy. A copy that is still fresh is kept.
+
+Examples:
+  vibe-skills cache warm
+  vibe-skills cache warm develop
+  vibe-skills cache warm v1.0.0 --cache-ttl 24h`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runCacheWarm,
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheStatsCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+	cacheCmd.AddCommand(cacheWarmCmd)
+}
+
+func runCacheStats(cmd *cobra.Command, args []string) error {
+	cache := registry.NewCacheWithTTL(flagCacheTTL)
+
+	stats, err := cache.Stats()
+	if err != nil {
+		return fmt.Errorf("failed to read cache: %w", err)
+	}
+
+	fmt.Fprintf(textOut, "Cache: %s\n", stats.Dir)
+	fmt.Fprintf(textOut, "Entries: %d (%s)\n", stats.Entries, formatBytes(stats.TotalSize))
+	if stats.Entries == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(textOut)
+	for _, entry := range stats.Details {
+		if entry.Ref == "" {
+			fmt.Fprintf(textOut, "  %-24s unreadable  %8s  (%s)\n", "?", formatBytes(entry.Size), entry.File)
+			continue
+		}
+
+		status := "fresh"
+		if !entry.Valid {
+			status = "stale"
+		}
+		fmt.Fprintf(textOut, "  %-24s %-11s %8s  %d skill(s), %d with files cached, fetched %s ago\n",
+			entry.Ref, status, formatBytes(entry.Size), entry.Skills, entry.Files, entry.Age.Round(time.Second))
+	}
+	return nil
+}
+
+func runCacheClear(cmd *cobra.Command, args []string) error {
+	cache := registry.NewCacheWithTTL(flagCacheTTL)
+
+	if len(args) == 1 {
+		ref := args[0]
+		reg, err := getRegistry()
+		if err != nil {
+			return fmt.Errorf("failed to create registry: %w", err)
+		}
+
+		// The ref in the configured registry, or a key as listed by cache stats
+		keys := []string{reg.CacheKey(ref)}
+		if keys[0] != ref {
+			keys = append(keys, ref)
+		}
+		for _, key := range keys {
+			err := cache.ClearRef(key)
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to clear cache for %s: %w", key, err)
+			}
+			fmt.Fprintf(textOut, "Removed cached registry for %s\n", key)
+			return nil
+		}
+		fmt.Fprintf(textOut, "Nothing cached for %s\n", ref)
+		return nil
+	}
+
+	stats, err := cache.Stats()
+	if err != nil {
+		return fmt.Errorf("failed to read cache: %w", err)
+	}
+	if stats.Entries == 0 {
+		fmt.Fprintln(textOut, "Cache is already empty")
+		return nil
+	}
+
+	if err := cache.Clear(); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+	fmt.Fprintf(textOut, "Removed %d cache entry(s) (%s)\n", stats.Entries, formatBytes(stats.TotalSize))
+	return nil
+}
+
+func runCacheWarm(cmd *cobra.Command, args []string) error {
+	if len(args) == 1 {
+		flagRef = args[0]
+	}
+
+	reg, err := getRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to create registry: %w", err)
+	}
+
+	cached, err := reg.Warm()
+	if err != nil {
+		return fmt.Errorf("failed to warm cache: %w", err)
+	}
+
+	skills, err := reg.List()
+	if err != nil {
+		return fmt.Errorf("failed to read cached index: %w", err)
+	}
+
+	state := "fetched"
+	if cached {
+		state = "already cached"
+	}
+	fmt.Fprintf(textOut, "%s %s: %s (%d skill(s))\n", markOK(), reg.GetRef(), state, len(skills))
+	return nil
+}
+
+// formatBytes renders a byte count in B, KB or MB
+func formatBytes(n int64) string {
+	switch {
+	case n < 1<<10:
+		return fmt.Sprintf("%d B", n)
+	case n < 1<<20:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	}
+}
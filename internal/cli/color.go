@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"os"
+)
+
+// ANSI color codes for the status markers
+const (
+	colorReset  = "\033[0m"
+	colorRed    = "\033[31m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+)
+
+// useColor is set once flags are parsed; when false, markers are plain ASCII
+var useColor = true
+
+// setColor disables color for --no-color, NO_COLOR (https://no-color.org),
+// TERM=dumb, and when stdout is not a terminal
+func setColor() {
+	useColor = !flagNoColor && os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb" && isTerminal(os.Stdout)
+}
+
+func colorize(color, s string) string {
+	return color + s + colorReset
+}
+
+// markOK marks a success
+func markOK() string {
+	if !useColor {
+		return "[ok]"
+	}
+	return colorize(colorGreen, "✓")
+}
+
+// markFail marks a failure
+func markFail() string {
+	if !useColor {
+		return "[fail]"
+	}
+	return colorize(colorRed, "✗")
+}
+
+// markWarn marks a warning or a skipped item
+func markWarn() string {
+	if !useColor {
+		return "[warn]"
+	}
+	return colorize(colorYellow, "⚠")
+}
+
+// markHint introduces a hint or a detail line
+func markHint() string {
+	if !useColor {
+		return "->"
+	}
+	return "→"
+}
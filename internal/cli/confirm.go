@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// errAborted is returned when the user declines a confirmation prompt
+var errAborted = errors.New("aborted")
+
+// confirm asks a yes/no question on stdin, defaulting to no. With --yes it
+// returns true without asking. When stdin is not a terminal nobody can
+// answer, so nonInteractive is returned instead.
+func confirm(question string, nonInteractive bool) bool {
+	if flagYes {
+		return true
+	}
+	if !isTerminal(os.Stdin) {
+		return nonInteractive
+	}
+
+	fmt.Fprintf(textOut, "%s [y/N] ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,250 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cuongtl1992/vibe-skills/internal/installer"
+	"github.com/cuongtl1992/vibe-skills/internal/registry"
+	"github.com/cuongtl1992/vibe-skills/internal/updater"
+	"github.com/cuongtl1992/vibe-skills/internal/version"
+	"github.com/spf13/cobra"
+)
+
+// doctorUpdateTimeout bounds the release check so doctor stays quick offline
+const doctorUpdateTimeout = 10 * time.Second
+
+var doctorFix bool
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose common problems",
+	Long: `Run a set of checks and report each as pass, warn or fail with a hint:
+registry connectivity, cache access, the skills directory layout, the
+integrity of installed skills, and whether the CLI is up to date.
+
+Examples:
+  vibe-skills doctor         # Run all checks
+  vibe-skills doctor --fix   # Also reinstall broken skills from the registry`,
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Reinstall broken skills, discarding local changes")
+}
+
+type checkStatus int
+
+const (
+	checkPass checkStatus = iota
+	checkWarn
+	checkFail
+)
+
+// checkResult is the outcome of a single doctor check
+type checkResult struct {
+	name   string
+	status checkStatus
+	detail string
+	hint   string // What the user can do about a warning or failure
+}
+
+func (r checkResult) print() {
+	symbol := markOK()
+	switch r.status {
+	case checkWarn:
+		symbol = markWarn()
+	case checkFail:
+		symbol = markFail()
+	}
+	fmt.Fprintf(textOut, "  %s %s: %s\n", symbol, r.name, r.detail)
+	if r.hint != "" && r.status != checkPass {
+		fmt.Fprintf(textOut, "      %s %s\n", markHint(), r.hint)
+	}
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	opts, err := registryOptions()
+	if err != nil {
+		return fmt.Errorf("failed to create registry: %w", err)
+	}
+	reg := registry.NewGitHubRegistry(opts)
+	inst := newInstaller(reg, cwd)
+
+	broken, skillsCheck := checkInstalledSkills(inst)
+	results := []checkResult{
+		checkRegistry(opts),
+		checkCache(opts.Cache),
+		checkLayout(inst),
+		skillsCheck,
+		checkVersion(),
+	}
+
+	fmt.Fprintln(textOut, "Running checks...")
+	failed := 0
+	for _, result := range results {
+		result.print()
+		if result.status == checkFail {
+			failed++
+		}
+	}
+
+	if doctorFix && len(broken) > 0 {
+		repaired, errors := inst.Repair(broken)
+		if len(repaired) > 0 {
+			fmt.Fprintf(textOut, "\nRepaired %d skill(s):\n", len(repaired))
+			for _, name := range repaired {
+				fmt.Fprintf(textOut, "  %s %s\n", markOK(), name)
+			}
+		}
+		if len(errors) > 0 {
+			fmt.Fprintf(textOut, "\nFailed to repair %d skill(s):\n", len(errors))
+			for _, err := range errors {
+				printFailure(err)
+			}
+			return fmt.Errorf("some skills failed to repair")
+		}
+		failed--
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d check(s) failed", failed)
+	}
+	return nil
+}
+
+// checkRegistry fetches the index directly from GitHub, bypassing the cache
+func checkRegistry(opts *registry.GitHubRegistryOptions) checkResult {
+	result := checkResult{name: "Registry"}
+	if opts.Offline {
+		result.status = checkWarn
+		result.detail = "skipped (--offline)"
+		return result
+	}
+
+	fresh := *opts
+	fresh.NoCache = true
+	fresh.OnStale = nil
+	skills, err := registry.NewGitHubRegistry(&fresh).List()
+	if err != nil {
+		result.status = checkFail
+		result.detail = fmt.Sprintf("%s is not reachable: %s", opts.Ref, err)
+		result.hint = "check your network connection and the --branch/--ref in use, or work from the cache with --offline"
+		return result
+	}
+
+	result.detail = fmt.Sprintf("%s is reachable (%d skills)", opts.Ref, len(skills))
+	return result
+}
+
+// checkCache confirms the cache directory can be read and written
+func checkCache(cache *registry.Cache) checkResult {
+	result := checkResult{name: "Cache", hint: "check the permissions of " + cache.Dir() + ", or run 'vibe-skills cache clear'"}
+
+	stats, err := cache.Stats()
+	if err != nil {
+		result.status = checkWarn
+		result.detail = fmt.Sprintf("cannot read %s: %s", cache.Dir(), err)
+		return result
+	}
+
+	if err := os.MkdirAll(cache.Dir(), 0755); err != nil {
+		result.status = checkWarn
+		result.detail = fmt.Sprintf("cannot create %s: %s", cache.Dir(), err)
+		return result
+	}
+	probe, err := os.CreateTemp(cache.Dir(), ".doctor-*")
+	if err != nil {
+		result.status = checkWarn
+		result.detail = fmt.Sprintf("%s is not writable: %s", cache.Dir(), err)
+		return result
+	}
+	_ = probe.Close()
+	_ = os.Remove(probe.Name())
+
+	result.detail = fmt.Sprintf("%s is writable (%d entries, %s)", cache.Dir(), stats.Entries, formatBytes(stats.TotalSize))
+	return result
+}
+
+// checkLayout reports leftovers of interrupted operations in the skills directory
+func checkLayout(inst *installer.Installer) checkResult {
+	result := checkResult{name: "Skills directory"}
+
+	problems, err := inst.CheckLayout()
+	if err != nil {
+		result.status = checkFail
+		result.detail = err.Error()
+		result.hint = "fix or delete the lockfile, then reinstall your skills"
+		return result
+	}
+	if len(problems) > 0 {
+		result.status = checkWarn
+		result.detail = strings.Join(problems, "; ")
+		result.hint = "remove leftover directories, and reinstall or remove skills missing from disk"
+		return result
+	}
+
+	result.detail = inst.SkillsDir() + " is well-formed"
+	return result
+}
+
+// checkInstalledSkills verifies installed skills against their manifests and
+// returns the broken ones for repair
+func checkInstalledSkills(inst *installer.Installer) ([]string, checkResult) {
+	result := checkResult{name: "Installed skills"}
+
+	broken, err := inst.Verify()
+	if err != nil {
+		result.status = checkFail
+		result.detail = fmt.Sprintf("failed to check: %s", err)
+		return nil, result
+	}
+	if len(broken) > 0 {
+		result.status = checkFail
+		result.detail = fmt.Sprintf("%d broken or modified: %s", len(broken), strings.Join(broken, ", "))
+		result.hint = "run 'vibe-skills doctor --fix' to reinstall them"
+		return broken, result
+	}
+
+	installed, _ := inst.ListInstalled()
+	result.detail = fmt.Sprintf("%d intact", len(installed))
+	return nil, result
+}
+
+// checkVersion reports whether a newer release of the CLI is available
+func checkVersion() checkResult {
+	result := checkResult{name: "CLI version"}
+
+	current := version.GetVersion()
+	if _, err := version.ParseSemver(current); err != nil {
+		result.status = checkWarn
+		result.detail = fmt.Sprintf("%s is a development build, not checked", current)
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), doctorUpdateTimeout)
+	defer cancel()
+
+	latest, hasUpdate, err := updater.CheckForUpdateContext(ctx, updater.ChannelStable)
+	switch {
+	case err != nil:
+		result.status = checkWarn
+		result.detail = fmt.Sprintf("could not check for updates: %s", err)
+	case hasUpdate:
+		result.status = checkWarn
+		result.detail = fmt.Sprintf("%s is available (running %s)", latest, current)
+		result.hint = "run 'vibe-skills self-update'"
+	default:
+		result.detail = fmt.Sprintf("%s is the latest", current)
+	}
+	return result
+}
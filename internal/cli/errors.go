@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cuongtl1992/vibe-skills/internal/installer"
+)
+
+// errorHint suggests what the user can do about err, or returns ""
+func errorHint(err error) string {
+	switch {
+	case errors.Is(err, installer.ErrNetwork):
+		return "check your network connection, or use --offline to work from the cache"
+	case errors.Is(err, installer.ErrSkillNotFound):
+		return "run 'vibe-skills search <query>' to find the skill's name"
+	case errors.Is(err, installer.ErrNotInstalled):
+		return "run 'vibe-skills list --installed' to see installed skills"
+	case errors.Is(err, installer.ErrConflict):
+		return "move your files out of the skill directory, or use --force to overwrite them"
+	case errors.Is(err, installer.ErrPermission):
+		return "check that you can write to the skills directory, or use --global or --target"
+	}
+	return ""
+}
+
+// printFailure prints a failed item of a batch with a hint when one applies
+func printFailure(err error) {
+	fmt.Fprintf(textOut, "  %s %s\n", markFail(), err)
+	if hint := errorHint(err); hint != "" {
+		fmt.Fprintf(textOut, "      %s %s\n", markHint(), hint)
+	}
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"errors"
+
+	"github.com/cuongtl1992/vibe-skills/internal/installer"
+)
+
+// Exit codes returned by the CLI
+const (
+	ExitOK       = 0
+	ExitError    = 1 // Any failure not covered below
+	ExitNetwork  = 2 // The registry or GitHub could not be reached
+	ExitNotFound = 3 // A skill does not exist or is not installed
+	ExitPartial  = 4 // Some skills of a batch succeeded and some failed
+)
+
+// batchError is returned by commands acting on several skills when some of
+// them failed; the per-skill failures have already been printed
+type batchError struct {
+	msg       string
+	succeeded int
+	failures  []error
+}
+
+// newBatchError records the outcome of a batch with at least one failure
+func newBatchError(msg string, succeeded int, failures []error) error {
+	return &batchError{msg: msg, succeeded: succeeded, failures: failures}
+}
+
+func (e *batchError) Error() string {
+	return e.msg
+}
+
+func (e *batchError) Unwrap() []error {
+	return e.failures
+}
+
+// exitCode maps err to the process exit code. A batch in which nothing
+// succeeded gets the code its failures share, or ExitError if they differ.
+func exitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var batch *batchError
+	if !errors.As(err, &batch) {
+		return errorClass(err)
+	}
+	if batch.succeeded > 0 {
+		return ExitPartial
+	}
+
+	code := ExitError
+	for idx, failure := range batch.failures {
+		class := errorClass(failure)
+		if idx > 0 && class != code {
+			return ExitError
+		}
+		code = class
+	}
+	return code
+}
+
+// errorClass returns the exit code for a single error
+func errorClass(err error) int {
+	switch {
+	case errors.Is(err, installer.ErrNetwork):
+		return ExitNetwork
+	case errors.Is(err, installer.ErrSkillNotFound), errors.Is(err, installer.ErrNotInstalled):
+		return ExitNotFound
+	}
+	return ExitError
+}
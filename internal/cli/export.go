@@ -0,0 +1,217 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/cuongtl1992/vibe-skills/internal/installer"
+	"github.com/cuongtl1992/vibe-skills/internal/logging"
+	"github.com/cuongtl1992/vibe-skills/internal/registry"
+	"github.com/spf13/cobra"
+)
+
+// exportVersion is the format version written by export and accepted by import
+const exportVersion = 1
+
+// skillSet is the document written by export and read by import
+type skillSet struct {
+	Version int             `json:"version"`
+	Skills  []exportedSkill `json:"skills"`
+}
+
+// exportedSkill records an installed skill and where it was installed from
+type exportedSkill struct {
+	Name  string `json:"name"`
+	Stack string `json:"stack,omitempty"`
+	Ref   string `json:"ref,omitempty"`
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the installed skill set as JSON",
+	Long: `Print the installed skills and the refs they were installed from as JSON,
+so the same set can be restored elsewhere with 'vibe-skills import'.
+
+Examples:
+  vibe-skills export > skills.json
+  vibe-skills export --global > global-skills.json`,
+	Args: cobra.NoArgs,
+	RunE: runExport,
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Install the skills listed in an export file",
+	Long: `Install every skill listed in a file written by 'vibe-skills export'.
+
+Registry skills are installed from the ref recorded in the file unless
+--ref or --branch is given. Skills that are already installed are skipped.
+
+Examples:
+  vibe-skills import skills.json
+  vibe-skills import --ref main skills.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImport,
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	reg, err := getRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to create registry: %w", err)
+	}
+
+	inst := newInstaller(reg, cwd)
+	installed, err := inst.ListInstalled()
+	if err != nil {
+		return fmt.Errorf("failed to list installed skills: %w", err)
+	}
+
+	locks := make(map[installer.Scope]*installer.Lock)
+	set := skillSet{Version: exportVersion, Skills: make([]exportedSkill, 0, len(installed))}
+	for _, name := range installed {
+		scope, _ := inst.InstalledScope(name)
+		lock, ok := locks[scope]
+		if !ok {
+			if lock, err = inst.ReadLockFor(scope); err != nil {
+				return fmt.Errorf("failed to read lockfile: %w", err)
+			}
+			locks[scope] = lock
+		}
+
+		skill := exportedSkill{Name: name}
+		if entry, ok := lock.Skills[name]; ok {
+			skill.Stack = entry.Stack
+			skill.Ref = entry.Ref
+		}
+		set.Skills = append(set.Skills, skill)
+	}
+
+	return printJSON(set)
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	set, err := readSkillSet(args[0])
+	if err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	opts, err := registryOptions()
+	if err != nil {
+		return fmt.Errorf("failed to create registry: %w", err)
+	}
+	checker := newInstaller(registry.NewGitHubRegistry(opts), cwd)
+
+	var installed, skipped []string
+	var errors []error
+
+	// Group registry skills by ref so each registry index is fetched once
+	byRef := make(map[string][]string)
+	for _, skill := range set.Skills {
+		if checker.IsInstalled(skill.Name) {
+			skipped = append(skipped, skill.Name)
+			continue
+		}
+
+		if source := skillSource(skill); source != "" {
+			name, err := installSource(cwd, source)
+			if err != nil {
+				errors = append(errors, fmt.Errorf("%s: %w", skill.Name, err))
+			} else {
+				installed = append(installed, name)
+			}
+			continue
+		}
+
+		ref := skill.Ref
+		if ref == "" || flagRef != "" || flagBranch != "" {
+			ref = opts.Ref
+		}
+		byRef[ref] = append(byRef[ref], skill.Name)
+	}
+
+	refs := make([]string, 0, len(byRef))
+	for ref := range byRef {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+
+	for _, ref := range refs {
+		refOpts := *opts
+		refOpts.Ref = ref
+		reg := registry.NewGitHubRegistry(&refOpts)
+		logging.Infof("Using registry: %s\n", reg.GetRef())
+
+		inst := newInstaller(reg, cwd)
+		i, e := inst.InstallMultiple(byRef[ref])
+		installed = append(installed, i...)
+		errors = append(errors, e...)
+	}
+
+	// Print results
+	if len(installed) > 0 {
+		logging.Infof("\nInstalled %d skill(s):\n", len(installed))
+		for _, name := range installed {
+			logging.Infof("  %s %s\n", markOK(), name)
+		}
+	}
+	if len(skipped) > 0 {
+		logging.Infof("\nSkipped %d skill(s) already installed:\n", len(skipped))
+		for _, name := range skipped {
+			logging.Infof("  %s %s\n", markWarn(), name)
+		}
+	}
+	if len(errors) > 0 {
+		fmt.Fprintf(textOut, "\nFailed to install %d skill(s):\n", len(errors))
+		for _, err := range errors {
+			printFailure(err)
+		}
+	}
+
+	logging.Infof("\n%d installed, %d skipped, %d failed\n", len(installed), len(skipped), len(errors))
+	if len(errors) > 0 {
+		return newBatchError("some skills failed to install", len(installed), errors)
+	}
+	return nil
+}
+
+// readSkillSet loads and checks an export file
+func readSkillSet(path string) (*skillSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var set skillSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if set.Version != exportVersion {
+		return nil, fmt.Errorf("unsupported export version %d in %s", set.Version, path)
+	}
+	return &set, nil
+}
+
+// skillSource returns the local directory or Git URL a skill was installed
+// from, or "" for registry skills
+func skillSource(skill exportedSkill) string {
+	switch skill.Stack {
+	case registry.LocalStack:
+		return strings.TrimPrefix(skill.Ref, registry.LocalStack+":")
+	case registry.GitStack:
+		return skill.Ref
+	}
+	return ""
+}
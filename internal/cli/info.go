@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var infoJSON bool
+
+var infoCmd = &cobra.Command{
+	Use:   "info [skill]",
+	Short: "Show details about a skill",
+	Long: `Show a skill's description, stack, files and install status before installing it.
+
+Examples:
+  vibe-skills info code-reviewer
+  vibe-skills info common/code-reviewer --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runInfo,
+}
+
+func init() {
+	infoCmd.Flags().BoolVar(&infoJSON, "json", false, "Print the details as JSON")
+}
+
+// skillInfo is the information printed by the info command
+type skillInfo struct {
+	Name         string     `json:"name"`
+	Stack        string     `json:"stack"`
+	Description  string     `json:"description"`
+	Ref          string     `json:"ref"`
+	Files        []fileInfo `json:"files"`
+	Dependencies []string   `json:"dependencies,omitempty"`
+	Tags         []string   `json:"tags,omitempty"`
+	Installed    bool       `json:"installed"`
+	Scope        string     `json:"scope,omitempty"`
+	InstalledRef string     `json:"installed_ref,omitempty"`
+}
+
+type fileInfo struct {
+	Path string `json:"path"`
+	Size int    `json:"size"`
+}
+
+func runInfo(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	reg, err := getRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to create registry: %w", err)
+	}
+
+	inst := newInstaller(reg, cwd)
+
+	skill, err := reg.Find(args[0])
+	if err != nil {
+		return err
+	}
+
+	files, err := reg.GetFiles(skill)
+	if err != nil {
+		return fmt.Errorf("failed to fetch skill files: %w", err)
+	}
+
+	info := skillInfo{
+		Name:         skill.Name,
+		Stack:        skill.Stack,
+		Description:  skill.Description,
+		Ref:          reg.GetRef(),
+		Dependencies: skill.Dependencies,
+		Tags:         skill.Tags,
+	}
+	for path, content := range files {
+		info.Files = append(info.Files, fileInfo{Path: path, Size: len(content)})
+	}
+	sort.Slice(info.Files, func(a, b int) bool { return info.Files[a].Path < info.Files[b].Path })
+
+	if scope, ok := inst.InstalledScope(skill.Name); ok {
+		info.Installed = true
+		info.Scope = scope.String()
+		if lock, err := inst.ReadLockFor(scope); err == nil {
+			info.InstalledRef = lock.Skills[skill.Name].Ref
+		}
+	}
+
+	if infoJSON {
+		return printJSON(info)
+	}
+
+	fmt.Fprintf(textOut, "%s/%s\n", info.Stack, info.Name)
+	if info.Description != "" {
+		fmt.Fprintf(textOut, "  %s\n", info.Description)
+	}
+	fmt.Fprintln(textOut)
+	fmt.Fprintf(textOut, "Registry:  %s\n", info.Ref)
+	if len(info.Dependencies) > 0 {
+		fmt.Fprintf(textOut, "Requires:  %s\n", strings.Join(info.Dependencies, ", "))
+	}
+	if len(info.Tags) > 0 {
+		fmt.Fprintf(textOut, "Tags:      %s\n", strings.Join(info.Tags, ", "))
+	}
+	switch {
+	case !info.Installed:
+		fmt.Fprintln(textOut, "Installed: no")
+	case info.InstalledRef != "":
+		fmt.Fprintf(textOut, "Installed: yes (%s, from %s)\n", info.Scope, info.InstalledRef)
+	default:
+		fmt.Fprintf(textOut, "Installed: yes (%s)\n", info.Scope)
+	}
+
+	fmt.Fprintf(textOut, "\nFiles (%d):\n", len(info.Files))
+	for _, file := range info.Files {
+		fmt.Fprintf(textOut, "  %-40s %s\n", file.Path, formatBytes(int64(file.Size)))
+	}
+	return nil
+}
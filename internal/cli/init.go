@@ -30,11 +30,11 @@ func runInit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create config file: %w", err)
 	}
 
-	fmt.Printf("Created %s with default skills:\n", config.ConfigFileName)
+	fmt.Fprintf(textOut, "Created %s with default skills:\n", config.ConfigFileName)
 	for _, skill := range cfg.Skills {
-		fmt.Printf("  - %s\n", skill)
+		fmt.Fprintf(textOut, "  - %s\n", skill)
 	}
-	fmt.Println("\nRun 'vibe-skills install' to install these skills.")
+	fmt.Fprintln(textOut, "\nRun 'vibe-skills install' to install these skills.")
 
 	return nil
 }
@@ -3,17 +3,32 @@ package cli
 import (
 	"fmt"
 	"os"
+	"path"
+	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/cuongtl1992/vibe-skills/internal/config"
 	"github.com/cuongtl1992/vibe-skills/internal/installer"
+	"github.com/cuongtl1992/vibe-skills/internal/logging"
+	"github.com/cuongtl1992/vibe-skills/internal/registry"
 	"github.com/spf13/cobra"
 )
 
 var (
-	installStack string
-	installAll   bool
-	installForce bool
+	installStack  []string
+	installAll    bool
+	installForce  bool
+	installDryRun bool
+	installJobs   int
+	installOutput string
+	installHooks  bool
+	installOnly   []string
+	installAtomic bool
+	installTime   time.Duration
+	installCRLF   bool
+	crlfGiven     bool // Whether --crlf was passed, to keep recorded choices otherwise
 )
 
 var installCmd = &cobra.Command{
@@ -27,18 +42,64 @@ Examples:
   vibe-skills install                     # Install from .vibe-skills.yaml
   vibe-skills install commit-convention   # Install a specific skill
   vibe-skills install ef-core sql-opt     # Install multiple skills
+  vibe-skills install 'go-*'              # Install every skill whose name matches
+  vibe-skills install ./my-skill          # Install a skill from a local directory
+  vibe-skills install github.com/org/repo//skills/my-skill@v1.0.0  # Install from a Git repository
   vibe-skills install --stack dotnet      # Install all skills from a stack
-  vibe-skills install --all               # Install all available skills`,
+  vibe-skills install --stack go --stack testing  # Install the skills of several stacks
+  vibe-skills install --all               # Install all available skills
+  vibe-skills install --dry-run ef-core   # Show files that would be written
+  vibe-skills install --run-hooks my-tool # Run the skill's hooks/post-install script
+  vibe-skills install --only 'scripts' my-tool  # Install SKILL.md and the scripts folder only
+  vibe-skills install --atomic --stack go # Install the whole stack or nothing
+  vibe-skills install --output json-stream --all  # Print progress as JSON lines
+
+Skill names containing *, ? or [ are globs matched against registry skill
+names, or against stack/name when they contain a slash ('dotnet/*'). Quote
+them so the shell does not expand them.
+
+--only patterns use glob syntax against paths inside the skill; a pattern
+without a slash also matches file names and a matching folder selects its
+contents. SKILL.md is always installed, and updates keep the same patterns.
+Install again with --only '*' to get every file back.
+
+--crlf writes text files with Windows (CRLF) line endings; binary files and
+scripts are left as they are. Updates keep the choice until the skill is
+installed again with --crlf=false.
+
+With --atomic, a failure of any skill rolls back the others installed by the
+same command, restoring the versions they replaced. Skills from a local
+directory or Git URL cannot be installed atomically.
+
+--output json-stream writes one JSON object per line to stdout for each
+install phase, e.g. {"skill":"x","event":"writing","path":".../x/SKILL.md"},
+followed by a "summary" event listing the installed skills and errors.
+Human-readable output goes to stderr instead. Events are resolving,
+fetching, writing, hook, done and error.`,
 	RunE: runInstall,
 }
 
 func init() {
-	installCmd.Flags().StringVarP(&installStack, "stack", "s", "", "Install all skills from specified stack(s), comma-separated")
+	installCmd.Flags().StringSliceVarP(&installStack, "stack", "s", nil, "Install all skills from specified stack(s); repeat or comma-separate for several")
 	installCmd.Flags().BoolVarP(&installAll, "all", "a", false, "Install all available skills")
 	installCmd.Flags().BoolVarP(&installForce, "force", "f", false, "Overwrite existing skills")
+	installCmd.Flags().IntVarP(&installJobs, "parallel", "j", installer.DefaultMaxParallel, "Number of skills to install concurrently")
+	installCmd.Flags().BoolVar(&installDryRun, "dry-run", false, "Show the files that would be written without installing")
+	installCmd.Flags().BoolVar(&installHooks, "run-hooks", false, "Run pre-install and post-install hook scripts shipped with skills")
+	installCmd.Flags().StringSliceVar(&installOnly, "only", nil, "Only install skill files matching these glob patterns (SKILL.md is always installed)")
+	installCmd.Flags().BoolVar(&installCRLF, "crlf", false, "Write text files with CRLF line endings, for Windows editors (updates keep the choice)")
+	installCmd.Flags().DurationVar(&installTime, "timeout", 0, "Stop starting new skills after this long, e.g. 2m (0 for no limit)")
+	installCmd.Flags().StringVar(&installOutput, "output", outputText, "Output format: text, or json-stream for one JSON event per line on stdout")
+	installCmd.Flags().BoolVar(&installAtomic, "atomic", false, "Roll back every skill installed by this command if any skill fails")
 }
 
 func runInstall(cmd *cobra.Command, args []string) error {
+	restoreOutput, err := startOutput(installOutput)
+	if err != nil {
+		return err
+	}
+	defer restoreOutput()
+
 	cwd, err := os.Getwd()
 	if err != nil {
 		return fmt.Errorf("failed to get current directory: %w", err)
@@ -49,28 +110,78 @@ func runInstall(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create registry: %w", err)
 	}
 
-	fmt.Printf("Using registry: %s\n\n", reg.GetRef())
+	logging.Infof("Using registry: %s\n\n", reg.GetRef())
 
-	inst := installer.New(reg, cwd)
+	crlfGiven = cmd.Flags().Changed("crlf")
+
+	inst := newInstaller(reg, cwd)
+	inst.SetMaxParallel(installJobs)
+	inst.SetForce(installForce)
+	enableHooks(inst, installHooks)
+	streamProgress(inst)
+	inst.SetAtomic(installAtomic)
+	setLineEndings(inst)
+	if err := inst.SetOnlyFiles(installOnly); err != nil {
+		return err
+	}
+
+	if installDryRun {
+		return runInstallDryRun(inst, reg, cwd, args)
+	}
+
+	ctx, cancel, err := timeoutContext(installTime)
+	if err != nil {
+		return err
+	}
+	defer cancel()
 
-	var installed []string
+	var results []installer.InstallResult
 	var errors []error
 
 	switch {
 	case installAll:
-		installed, errors = inst.InstallAll()
+		r, err := inst.InstallAllResultsContext(ctx)
+		if err != nil {
+			errors = append(errors, err)
+		}
+		results = r
 
-	case installStack != "":
-		stacks := strings.Split(installStack, ",")
-		for _, stack := range stacks {
-			stack = strings.TrimSpace(stack)
-			i, e := inst.InstallStack(stack)
-			installed = append(installed, i...)
-			errors = append(errors, e...)
+	case len(installStack) > 0:
+		for idx := range installStack {
+			installStack[idx] = strings.TrimSpace(installStack[idx])
+		}
+		r, err := inst.InstallStacksResultsContext(ctx, installStack)
+		if err != nil {
+			errors = append(errors, err)
 		}
+		results = r
 
 	case len(args) > 0:
-		installed, errors = inst.InstallMultiple(args)
+		var names []string
+		for _, arg := range args {
+			if installAtomic && isSkillSource(arg) {
+				return fmt.Errorf("--atomic cannot install %s: only registry skills can be rolled back", arg)
+			}
+		}
+		args, errors = expandNamePatterns(reg, args)
+		if installAtomic && len(errors) > 0 {
+			break
+		}
+		for _, arg := range args {
+			if !isSkillSource(arg) {
+				names = append(names, arg)
+				continue
+			}
+			result, err := installSourceResult(cwd, arg)
+			if err != nil {
+				errors = append(errors, fmt.Errorf("%s: %w", arg, err))
+			} else {
+				results = append(results, result)
+			}
+		}
+		if len(names) > 0 {
+			results = append(results, inst.InstallMultipleResultsContext(ctx, names)...)
+		}
 
 	default:
 		// Install from config file
@@ -78,28 +189,309 @@ func runInstall(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return fmt.Errorf("no skills specified and no config file found: run 'vibe-skills init' to create a config file, or specify skills to install")
 		}
-		installed, errors = inst.InstallMultiple(cfg.Skills)
+		results = inst.InstallMultipleResultsContext(ctx, cfg.Skills)
 	}
 
+	var installed []installer.InstallResult
+	for _, result := range results {
+		switch result.Status {
+		case installer.StatusInstalled:
+			installed = append(installed, result)
+		case installer.StatusFailed:
+			errors = append(errors, fmt.Errorf("%s: %w", result.Name, result.Err))
+		}
+	}
+
+	var installedNames []string
+	for _, result := range installed {
+		installedNames = append(installedNames, result.Name)
+	}
+	stream.write(streamEvent{Event: "summary", Installed: installedNames, Errors: errorStrings(errors)})
+
 	// Print results
 	if len(installed) > 0 {
-		fmt.Printf("Installed %d skill(s):\n", len(installed))
-		for _, name := range installed {
-			fmt.Printf("  ✓ %s\n", name)
+		logging.Infof("Installed %d skill(s):\n", len(installed))
+		var totalFiles int
+		var totalBytes int64
+		for _, result := range installed {
+			logging.Infof("  %s %s: %s\n", markOK(), installedLabel(result), formatFootprint(result.FilesWritten, result.BytesWritten))
+			totalFiles += result.FilesWritten
+			totalBytes += result.BytesWritten
+		}
+		if len(installed) > 1 {
+			logging.Infof("Total: %s\n", formatFootprint(totalFiles, totalBytes))
 		}
+		warnGitTracked(inst, installedNames)
 	}
 
 	if len(errors) > 0 {
-		fmt.Printf("\nFailed to install %d skill(s):\n", len(errors))
+		fmt.Fprintf(textOut, "\nFailed to install %d skill(s):\n", len(errors))
 		for _, err := range errors {
-			fmt.Printf("  ✗ %s\n", err)
+			printFailure(err)
+		}
+		printTimeout(ctx, installTime)
+		if installAtomic {
+			logging.Infof("\nNo skills were installed (--atomic).\n")
 		}
-		return fmt.Errorf("some skills failed to install")
+		return newBatchError("some skills failed to install", len(installed), errors)
 	}
 
 	if len(installed) == 0 {
-		fmt.Println("No skills to install.")
+		logging.Infof("No skills to install.\n")
 	}
 
 	return nil
 }
+
+// installedLabel names an installed skill, with the stacks that selected it
+// when more than one stack was installed
+func installedLabel(result installer.InstallResult) string {
+	if len(installStack) > 1 && len(result.Stacks) > 0 {
+		return fmt.Sprintf("%s (%s)", result.Name, strings.Join(result.Stacks, ", "))
+	}
+	return result.Name
+}
+
+// formatFootprint renders a file count and size such as "4 files, 18.0 KB"
+func formatFootprint(files int, size int64) string {
+	unit := "files"
+	if files == 1 {
+		unit = "file"
+	}
+	return fmt.Sprintf("%d %s, %s", files, unit, formatBytes(size))
+}
+
+// isNamePattern reports whether arg is a glob over registry skill names
+func isNamePattern(arg string) bool {
+	return !isSkillSource(arg) && strings.ContainsAny(arg, "*?[")
+}
+
+// expandNamePatterns replaces each glob in args with the registry skills
+// whose name matches it, or whose stack/name does for a glob with a slash,
+// reporting how many matched. Globs matching nothing are returned as errors.
+func expandNamePatterns(reg registry.Registry, args []string) (names []string, errors []error) {
+	var skills []registry.Skill
+	for _, arg := range args {
+		if !isNamePattern(arg) {
+			names = append(names, arg)
+			continue
+		}
+		if _, err := path.Match(arg, ""); err != nil {
+			errors = append(errors, fmt.Errorf("invalid pattern %q: %w", arg, err))
+			continue
+		}
+		if skills == nil {
+			var err error
+			if skills, err = reg.List(); err != nil {
+				errors = append(errors, fmt.Errorf("%s: failed to list skills: %w", arg, err))
+				continue
+			}
+		}
+
+		var matches []string
+		for _, skill := range skills {
+			candidate := skill.Name
+			if strings.Contains(arg, "/") {
+				candidate = skill.Stack + "/" + skill.Name
+			}
+			if ok, _ := path.Match(arg, candidate); ok {
+				matches = append(matches, skill.Name)
+			}
+		}
+		if len(matches) == 0 {
+			errors = append(errors, fmt.Errorf("%w: no skills match %s", installer.ErrSkillNotFound, arg))
+			continue
+		}
+		sort.Strings(matches)
+		logging.Infof("%s matched %d skill(s): %s\n", arg, len(matches), strings.Join(matches, ", "))
+		names = append(names, matches...)
+	}
+	return names, errors
+}
+
+// isSkillSource reports whether arg names a skill outside the registry
+func isSkillSource(arg string) bool {
+	return registry.IsLocalPath(arg) || registry.IsGitURL(arg)
+}
+
+// sourceProvider returns a provider serving the single skill in a local
+// directory or Git repository, together with the skill's name
+func sourceProvider(source string) (installer.SkillProvider, string, error) {
+	if registry.IsGitURL(source) {
+		git, err := registry.NewGitProvider(source)
+		if err != nil {
+			return nil, "", err
+		}
+		return git, git.Skill().Name, nil
+	}
+
+	local, err := registry.NewLocalProvider(source)
+	if err != nil {
+		return nil, "", err
+	}
+	return local, local.Skill().Name, nil
+}
+
+// installSource installs the skill in a local directory or Git repository and returns its name
+func installSource(cwd, source string) (string, error) {
+	result, err := installSourceResult(cwd, source)
+	if err != nil {
+		return "", err
+	}
+	return result.Name, result.Err
+}
+
+// installSourceResult is installSource reporting the files and bytes written.
+// Errors opening the source are returned rather than as a failed result.
+func installSourceResult(cwd, source string) (installer.InstallResult, error) {
+	provider, name, err := sourceProvider(source)
+	if err != nil {
+		return installer.InstallResult{}, err
+	}
+
+	inst := newInstaller(provider, cwd)
+	inst.SetForce(installForce)
+	enableHooks(inst, installHooks)
+	setLineEndings(inst)
+	if err := inst.SetOnlyFiles(installOnly); err != nil {
+		return installer.InstallResult{}, err
+	}
+
+	return inst.InstallWithResult(name), nil
+}
+
+// setLineEndings applies --crlf when given, leaving skills installed before
+// with their recorded line endings otherwise
+func setLineEndings(inst *installer.Installer) {
+	if crlfGiven {
+		inst.SetCRLF(installCRLF)
+	}
+}
+
+// enableHooks turns on skill hook scripts and echoes their output
+func enableHooks(inst *installer.Installer, enabled bool) {
+	if !enabled {
+		return
+	}
+	inst.SetRunHooks(true)
+	inst.OnProgress(func(event installer.InstallEvent) {
+		if event.Phase != installer.PhaseHook {
+			return
+		}
+		logging.Infof("  %s %s: ran %s\n", markHint(), event.Skill, filepath.Base(event.Path))
+		for _, line := range strings.Split(strings.TrimRight(event.Output, "\n"), "\n") {
+			if line != "" {
+				logging.Infof("    %s\n", line)
+			}
+		}
+	})
+}
+
+// warnGitTracked warns about skills whose Git-tracked files the command just
+// changed, so the working-tree changes do not come as a surprise. It is
+// advisory: git failures are only logged with --verbose.
+func warnGitTracked(inst *installer.Installer, names []string) {
+	cwd, _ := os.Getwd()
+	for _, name := range names {
+		files, err := inst.ModifiedTrackedFiles(name)
+		if err != nil {
+			logging.Verbosef("%s: failed to check Git status: %v\n", name, err)
+			continue
+		}
+		if len(files) == 0 {
+			continue
+		}
+
+		scope, _ := inst.InstalledScope(name)
+		dir := filepath.Join(inst.SkillsDirFor(scope), name)
+		if rel, err := filepath.Rel(cwd, dir); err == nil && !strings.HasPrefix(rel, "..") {
+			dir = rel
+		}
+		logging.Infof("  %s %s: changed %d file(s) tracked by Git in %s\n", markWarn(), name, len(files), dir)
+		logging.Infof("      %s review them with: git diff -- %s\n", markHint(), dir)
+	}
+}
+
+// runInstallDryRun prints the files each selected skill would write
+func runInstallDryRun(inst *installer.Installer, reg *registry.GitHubRegistry, cwd string, args []string) error {
+	var names []string
+
+	switch {
+	case installAll:
+		skills, err := reg.List()
+		if err != nil {
+			return fmt.Errorf("failed to list skills: %w", err)
+		}
+		for _, skill := range skills {
+			names = append(names, skill.Name)
+		}
+
+	case len(installStack) > 0:
+		seen := make(map[string]bool)
+		for _, stack := range installStack {
+			skills, err := reg.ListByStack(strings.TrimSpace(stack))
+			if err != nil {
+				return fmt.Errorf("failed to list stack %s: %w", stack, err)
+			}
+			for _, skill := range skills {
+				if !seen[skill.Name] {
+					seen[skill.Name] = true
+					names = append(names, skill.Name)
+				}
+			}
+		}
+
+	case len(args) > 0:
+		var errors []error
+		names, errors = expandNamePatterns(reg, args)
+		for _, err := range errors {
+			fmt.Fprintf(textOut, "  %s %s\n", markFail(), err)
+		}
+		if len(errors) > 0 && len(names) == 0 {
+			return fmt.Errorf("no skills match")
+		}
+
+	default:
+		cfg, err := config.Load(cwd)
+		if err != nil {
+			return fmt.Errorf("no skills specified and no config file found: run 'vibe-skills init' to create a config file, or specify skills to install")
+		}
+		names = cfg.Skills
+	}
+
+	var failed int
+	for _, name := range names {
+		planner := inst
+		if isSkillSource(name) {
+			provider, skillName, err := sourceProvider(name)
+			if err != nil {
+				fmt.Fprintf(textOut, "  %s %s: %s\n", markFail(), name, err)
+				failed++
+				continue
+			}
+			planner = newInstaller(provider, cwd)
+			name = skillName
+		}
+
+		plan, err := planner.InstallDryRun(name)
+		if err != nil {
+			fmt.Fprintf(textOut, "  %s %s: %s\n", markFail(), name, err)
+			failed++
+			continue
+		}
+
+		fmt.Fprintf(textOut, "%s:\n", name)
+		for _, write := range plan {
+			action := "create"
+			if write.Exists {
+				action = "overwrite"
+			}
+			fmt.Fprintf(textOut, "  %-9s %s\n", action, write.Path)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to plan %d skill(s)", failed)
+	}
+	return nil
+}
@@ -1,10 +1,13 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/cuongtl1992/vibe-skills/internal/installer"
 	"github.com/cuongtl1992/vibe-skills/internal/registry"
@@ -13,28 +16,67 @@ import (
 
 var (
 	listStack     string
+	listTags      []string
 	listInstalled bool
+	listJSON      bool
 )
 
 var listCmd = &cobra.Command{
 	Use:     "list",
 	Aliases: []string{"ls"},
 	Short:   "List available skills",
-	Long: `List all available skills or filter by stack.
+	Long: `List all available skills or filter by stack or tag.
 
 Examples:
   vibe-skills list                    # List all available skills
   vibe-skills list --stack dotnet     # List skills in dotnet stack
-  vibe-skills list --installed        # List installed skills only
-  vibe-skills list --branch develop   # List skills from develop branch`,
+  vibe-skills list --tag security     # List skills tagged security
+  vibe-skills list --tag testing,bdd  # List skills with any of the tags
+  vibe-skills list --installed        # List installed skills and whether they are outdated
+  vibe-skills list --branch develop   # List skills from develop branch
+  vibe-skills list --json             # Print skills as a JSON array`,
 	RunE: runList,
 }
 
 func init() {
 	listCmd.Flags().StringVarP(&listStack, "stack", "s", "", "Filter by stack")
-	listCmd.Flags().BoolVarP(&listInstalled, "installed", "i", false, "List installed skills only")
+	listCmd.Flags().StringSliceVarP(&listTags, "tag", "t", nil, "Filter by tag; repeat or comma-separate to match any of several")
+	listCmd.Flags().BoolVarP(&listInstalled, "installed", "i", false, "List installed skills with their registry description and update status")
+	listCmd.Flags().BoolVar(&listJSON, "json", false, "Print skills as a JSON array")
 }
 
+// listedSkill is a registry skill as printed by list --json
+type listedSkill struct {
+	Name        string   `json:"name"`
+	Stack       string   `json:"stack"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags,omitempty"`
+	Installed   bool     `json:"installed"`
+}
+
+// installedSkill is an installed skill as printed by list --installed --json
+type installedSkill struct {
+	Name        string     `json:"name"`
+	Scope       string     `json:"scope"`
+	Stack       string     `json:"stack,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Ref         string     `json:"ref,omitempty"`
+	InstalledAt *time.Time `json:"installed_at,omitempty"`
+	Files       int        `json:"files"`
+	Size        int64      `json:"size"`
+	Status      string     `json:"status"`
+	Outdated    bool       `json:"outdated"`
+}
+
+// Statuses of an installed skill relative to the registry
+const (
+	statusCurrent  = "current"
+	statusOutdated = "outdated"
+	statusRemoved  = "removed" // No longer in the registry
+	statusLocal    = "local"   // Installed from a local directory or Git URL, or written by hand
+	statusUnknown  = "unknown" // The registry could not be checked
+)
+
 func runList(cmd *cobra.Command, args []string) error {
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -46,22 +88,33 @@ func runList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create registry: %w", err)
 	}
 
-	inst := installer.New(reg, cwd)
+	inst := newInstaller(reg, cwd)
 
 	if listInstalled {
-		installed, err := inst.ListInstalled()
+		installed, err := inst.ListInstalledDetailed()
 		if err != nil {
 			return fmt.Errorf("failed to list installed skills: %w", err)
 		}
 
-		if len(installed) == 0 {
-			fmt.Println("No skills installed in this project.")
+		skills := describeInstalled(inst, reg, installed)
+
+		if listJSON {
+			return printJSON(skills)
+		}
+
+		if len(skills) == 0 {
+			fmt.Fprintln(textOut, "No skills installed in this project.")
 			return nil
 		}
 
-		fmt.Printf("Installed skills (%d):\n", len(installed))
-		for _, name := range installed {
-			fmt.Printf("  %s\n", name)
+		fmt.Fprintf(textOut, "Installed skills (%d):\n", len(skills))
+		fmt.Fprintf(textOut, "  %-30s %-12s %-9s %5s %9s  %s\n", "SKILL", "STACK", "STATUS", "FILES", "SIZE", "DESCRIPTION")
+		for _, skill := range skills {
+			name := skill.Name
+			if skill.Scope == installer.ScopeGlobal.String() && !flagGlobal {
+				name += " (global)"
+			}
+			fmt.Fprintf(textOut, "  %-30s %-12s %-9s %5d %9s  %s\n", name, skill.Stack, skill.Status, skill.Files, formatBytes(skill.Size), skill.Description)
 		}
 		return nil
 	}
@@ -72,13 +125,13 @@ func runList(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return fmt.Errorf("failed to list skills: %w", err)
 		}
-		if len(skills) == 0 {
-			fmt.Printf("No skills found in stack: %s\n", listStack)
+		if len(skills) == 0 && !listJSON {
+			fmt.Fprintf(textOut, "No skills found in stack: %s\n", listStack)
 			stacks, _ := reg.GetStacks()
 			if len(stacks) > 0 {
-				fmt.Println("\nAvailable stacks:")
+				fmt.Fprintln(textOut, "\nAvailable stacks:")
 				for _, stack := range stacks {
-					fmt.Printf("  %s\n", stack)
+					fmt.Fprintf(textOut, "  %s\n", stack)
 				}
 			}
 			return nil
@@ -90,8 +143,33 @@ func runList(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if len(listTags) > 0 {
+		skills, err = filterByTags(reg, skills, listTags)
+		if err != nil {
+			return fmt.Errorf("failed to list skills: %w", err)
+		}
+		if len(skills) == 0 && !listJSON {
+			fmt.Fprintf(textOut, "No skills found with tag: %s\n", strings.Join(listTags, ", "))
+			return nil
+		}
+	}
+
+	if listJSON {
+		result := make([]listedSkill, 0, len(skills))
+		for _, skill := range skills {
+			result = append(result, listedSkill{
+				Name:        skill.Name,
+				Stack:       skill.Stack,
+				Description: skill.Description,
+				Tags:        skill.Tags,
+				Installed:   inst.IsInstalled(skill.Name),
+			})
+		}
+		return printJSON(result)
+	}
+
 	if len(skills) == 0 {
-		fmt.Println("No skills available.")
+		fmt.Fprintln(textOut, "No skills available.")
 		return nil
 	}
 
@@ -109,11 +187,11 @@ func runList(cmd *cobra.Command, args []string) error {
 	sort.Strings(stacks)
 
 	// Print header with registry info
-	fmt.Printf("Registry: %s\n", reg.GetRef())
+	fmt.Fprintf(textOut, "Registry: %s\n", reg.GetRef())
 
 	// Print grouped skills
 	for _, stack := range stacks {
-		fmt.Printf("\n%s:\n", strings.ToUpper(stack))
+		fmt.Fprintf(textOut, "\n%s:\n", strings.ToUpper(stack))
 		stackSkills := grouped[stack]
 		sort.Slice(stackSkills, func(i, j int) bool {
 			return stackSkills[i].Name < stackSkills[j].Name
@@ -125,12 +203,107 @@ func runList(cmd *cobra.Command, args []string) error {
 				installed = " [installed]"
 			}
 			if skill.Description != "" {
-				fmt.Printf("  %-25s %s%s\n", skill.Name, skill.Description, installed)
+				fmt.Fprintf(textOut, "  %-25s %s%s\n", skill.Name, skill.Description, installed)
 			} else {
-				fmt.Printf("  %s%s\n", skill.Name, installed)
+				fmt.Fprintf(textOut, "  %s%s\n", skill.Name, installed)
 			}
 		}
 	}
 
 	return nil
 }
+
+// filterByTags keeps the skills that carry any of tags, in their original order
+func filterByTags(reg registry.Registry, skills []registry.Skill, tags []string) ([]registry.Skill, error) {
+	tagged := make(map[string]bool)
+	for _, tag := range tags {
+		matches, err := reg.ListByTag(strings.TrimSpace(tag))
+		if err != nil {
+			return nil, err
+		}
+		for _, skill := range matches {
+			tagged[skill.Name] = true
+		}
+	}
+
+	var result []registry.Skill
+	for _, skill := range skills {
+		if tagged[skill.Name] {
+			result = append(result, skill)
+		}
+	}
+	return result, nil
+}
+
+// describeInstalled adds registry metadata to the installed skills and checks
+// which are outdated. The checks run concurrently since each fetches the
+// skill's files.
+func describeInstalled(inst *installer.Installer, reg *registry.GitHubRegistry, installed []installer.InstalledSkill) []installedSkill {
+	result := make([]installedSkill, len(installed))
+	for idx, skill := range installed {
+		result[idx] = installedSkill{
+			Name:  skill.Name,
+			Scope: skill.Scope.String(),
+			Stack: skill.Stack,
+			Ref:   skill.Ref,
+			Files: skill.Files,
+			Size:  skill.Size,
+		}
+		if skill.Tracked {
+			installedAt := skill.InstalledAt
+			result[idx].InstalledAt = &installedAt
+		}
+	}
+
+	sem := make(chan struct{}, installer.DefaultMaxParallel)
+	var wg sync.WaitGroup
+	for idx := range result {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(skill *installedSkill) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			checkInstalled(inst, reg, skill)
+		}(&result[idx])
+	}
+	wg.Wait()
+
+	return result
+}
+
+// checkInstalled fills in a skill's registry description and status
+func checkInstalled(inst *installer.Installer, reg *registry.GitHubRegistry, skill *installedSkill) {
+	if skill.Stack == registry.LocalStack || skill.Stack == registry.GitStack {
+		skill.Status = statusLocal
+		return
+	}
+
+	found, err := reg.Find(skill.Name)
+	switch {
+	case errors.Is(err, registry.ErrSkillNotFound) && skill.InstalledAt == nil:
+		// Not in the lockfile, so written by hand
+		skill.Status = statusLocal
+		return
+	case errors.Is(err, registry.ErrSkillNotFound):
+		skill.Status = statusRemoved
+		return
+	case err != nil:
+		skill.Status = statusUnknown
+		return
+	}
+	skill.Description = found.Description
+	if skill.Stack == "" {
+		skill.Stack = found.Stack
+	}
+
+	outdated, err := inst.CheckOutdated(skill.Name)
+	switch {
+	case err != nil:
+		skill.Status = statusUnknown
+	case outdated != nil:
+		skill.Status = statusOutdated
+		skill.Outdated = true
+	default:
+		skill.Status = statusCurrent
+	}
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/cuongtl1992/vibe-skills/internal/installer"
+	"github.com/cuongtl1992/vibe-skills/internal/logging"
+	"github.com/spf13/cobra"
+)
+
+var migrateTo string
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate <skills...> --to <global|project>",
+	Short: "Move installed skills between the project and global scope",
+	Long: `Move installed skills, with their lockfile entries, from the project's
+skills directory to ~/.claude/skills or back. Local changes move with them, so
+a skill tuned in one project can be promoted to every project.
+
+If the destination already has the skill, you are asked before it is
+replaced; with --yes it is replaced, and without a terminal it is kept.
+
+Examples:
+  vibe-skills migrate code-reviewer --to global
+  vibe-skills migrate commit-convention sql-opt --to project`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runMigrate,
+}
+
+func init() {
+	migrateCmd.Flags().StringVar(&migrateTo, "to", "", "Scope to move the skills to: global or project")
+	_ = migrateCmd.MarkFlagRequired("to")
+}
+
+func runMigrate(cmd *cobra.Command, args []string) error {
+	var to installer.Scope
+	switch migrateTo {
+	case "global":
+		to = installer.ScopeGlobal
+	case "project":
+		to = installer.ScopeProject
+	default:
+		return fmt.Errorf("invalid --to %q: expected global or project", migrateTo)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	// Names are looked up in both scopes, whichever --global says
+	inst := installer.NewWithTarget(nil, cwd, flagTarget)
+
+	var moved []string
+	var errs []error
+	for _, arg := range args {
+		name, err := inst.ResolveInstalledName(arg)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", arg, err))
+			continue
+		}
+
+		from, err := inst.Migrate(name, to, false)
+		if errors.Is(err, installer.ErrInstalledInDestination) {
+			if !confirm(fmt.Sprintf("%s is already installed in the %s scope. Replace it?", name, to), false) {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+				continue
+			}
+			from, err = inst.Migrate(name, to, true)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		moved = append(moved, fmt.Sprintf("%s (%s -> %s)", name, from, to))
+	}
+
+	if len(moved) > 0 {
+		logging.Infof("Moved %d skill(s) to %s:\n", len(moved), inst.SkillsDirFor(to))
+		for _, name := range moved {
+			logging.Infof("  %s %s\n", markOK(), name)
+		}
+	}
+
+	if len(errs) > 0 {
+		fmt.Fprintf(textOut, "\nFailed to migrate %d skill(s):\n", len(errs))
+		for _, err := range errs {
+			printFailure(err)
+		}
+		return newBatchError("some skills failed to migrate", len(moved), errs)
+	}
+	return nil
+}
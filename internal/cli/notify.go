@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cuongtl1992/vibe-skills/internal/config"
+	"github.com/cuongtl1992/vibe-skills/internal/updater"
+	"github.com/cuongtl1992/vibe-skills/internal/version"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// NoUpdateNotifierEnv disables the update notice when set to a non-empty value
+	NoUpdateNotifierEnv = "VIBE_SKILLS_NO_UPDATE_NOTIFIER"
+
+	// updateCheckFileName records when the update notice last checked for a release
+	updateCheckFileName = "last-update-check"
+
+	updateCheckInterval = 24 * time.Hour
+
+	// noticeTimeout bounds how long a command's exit waits for the check
+	noticeTimeout = time.Second
+)
+
+// updateNotice carries the result of the background release check, if one was started
+var updateNotice chan string
+
+// startUpdateCheck looks for a newer release in the background, at most once
+// per updateCheckInterval
+func startUpdateCheck(cmd *cobra.Command) {
+	if !notifierEnabled(cmd) {
+		return
+	}
+
+	stamp := updateCheckPath()
+	if info, err := os.Stat(stamp); err == nil && time.Since(info.ModTime()) < updateCheckInterval {
+		return
+	}
+	// Record the attempt up front so failing checks are throttled too
+	if err := touchFile(stamp); err != nil {
+		return
+	}
+
+	updateNotice = make(chan string, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), noticeTimeout)
+		defer cancel()
+
+		latest, hasUpdate, err := updater.CheckForUpdateContext(ctx, updater.ChannelStable)
+		if err != nil || !hasUpdate {
+			latest = ""
+		}
+		updateNotice <- latest
+	}()
+}
+
+// printUpdateNotice prints the hint from startUpdateCheck, waiting briefly for it
+func printUpdateNotice() {
+	if updateNotice == nil {
+		return
+	}
+
+	select {
+	case latest := <-updateNotice:
+		if latest != "" {
+			fmt.Fprintf(os.Stderr, "\nA new version %s is available, run 'vibe-skills self-update'\n", latest)
+		}
+	case <-time.After(noticeTimeout):
+	}
+}
+
+// notifierEnabled reports whether cmd should print the update notice
+func notifierEnabled(cmd *cobra.Command) bool {
+	if os.Getenv(NoUpdateNotifierEnv) != "" || flagOffline {
+		return false
+	}
+	// Development builds have nothing to compare against
+	if _, err := version.ParseSemver(version.GetVersion()); err != nil {
+		return false
+	}
+	// These commands check for updates themselves
+	switch cmd {
+	case versionCmd, selfUpdateCmd, doctorCmd:
+		return false
+	}
+	return true
+}
+
+func updateCheckPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, config.GlobalConfigDir, updateCheckFileName)
+}
+
+// touchFile creates path if needed and sets its modification time to now
+func touchFile(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	now := time.Now()
+	return os.Chtimes(path, now, now)
+}
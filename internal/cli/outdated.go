@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cuongtl1992/vibe-skills/internal/installer"
+	"github.com/spf13/cobra"
+)
+
+var outdatedSince string
+
+var outdatedCmd = &cobra.Command{
+	Use:   "outdated",
+	Short: "List installed skills with newer versions in the registry",
+	Long: `Compare installed skills with the registry and list those that changed
+since they were installed. Nothing is modified; run 'vibe-skills update' to upgrade.
+
+--since shows only skills the registry records as updated within the window,
+given as a duration such as 24h or 7d. Skills without an update time in the
+registry are still shown, with a note.
+
+Examples:
+  vibe-skills outdated
+  vibe-skills outdated --ref v2.0.0
+  vibe-skills outdated --since 7d`,
+	Args: cobra.NoArgs,
+	RunE: runOutdated,
+}
+
+func init() {
+	outdatedCmd.Flags().StringVar(&outdatedSince, "since", "", "Only show skills updated in the registry within this window, e.g. 24h or 7d")
+}
+
+func runOutdated(cmd *cobra.Command, args []string) error {
+	var since time.Duration
+	if outdatedSince != "" {
+		var err error
+		if since, err = parseSince(outdatedSince); err != nil {
+			return err
+		}
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	reg, err := getRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to create registry: %w", err)
+	}
+
+	inst := newInstaller(reg, cwd)
+
+	outdated, errors := inst.Outdated()
+
+	hidden, undated := 0, 0
+	if outdatedSince != "" {
+		cutoff := time.Now().Add(-since)
+		var recent []installer.OutdatedSkill
+		for _, skill := range outdated {
+			switch {
+			case skill.UpdatedAt == nil:
+				undated++
+				recent = append(recent, skill)
+			case skill.UpdatedAt.Before(cutoff):
+				hidden++
+			default:
+				recent = append(recent, skill)
+			}
+		}
+		outdated = recent
+	}
+
+	if len(outdated) == 0 && len(errors) == 0 {
+		if hidden > 0 {
+			fmt.Fprintf(textOut, "No skills updated in the last %s (%d older outdated skill(s) hidden).\n", outdatedSince, hidden)
+			return nil
+		}
+		fmt.Fprintln(textOut, "All installed skills are up to date.")
+		return nil
+	}
+
+	if len(outdated) > 0 {
+		fmt.Fprintf(textOut, "%-30s %-20s %-20s %s\n", "SKILL", "INSTALLED", "AVAILABLE", "UPDATED")
+		for _, skill := range outdated {
+			name := skill.Name
+			if skill.Scope == installer.ScopeGlobal && !flagGlobal {
+				name += " (global)"
+			}
+			installedRef := skill.InstalledRef
+			if installedRef == "" {
+				installedRef = "unknown"
+			}
+			updated := "unknown"
+			if skill.UpdatedAt != nil {
+				updated = skill.UpdatedAt.Local().Format("2006-01-02 15:04")
+			}
+			fmt.Fprintf(textOut, "%-30s %-20s %-20s %s\n", name, installedRef, skill.AvailableRef, updated)
+		}
+	}
+
+	if hidden > 0 {
+		fmt.Fprintf(textOut, "\n%d outdated skill(s) updated before the last %s hidden\n", hidden, outdatedSince)
+	}
+	if undated > 0 {
+		fmt.Fprintf(textOut, "\n%s %d skill(s) have no update time in the registry and are shown regardless\n", markHint(), undated)
+	}
+
+	if len(errors) > 0 {
+		fmt.Fprintf(textOut, "\nFailed to check %d skill(s):\n", len(errors))
+		for _, err := range errors {
+			printFailure(err)
+		}
+		// Skills that were checked count as successes for the exit code
+		installed, _ := inst.ListInstalled()
+		checked := max(len(installed)-len(errors), 0)
+		return newBatchError("some skills could not be checked", checked, errors)
+	}
+
+	return nil
+}
+
+// parseSince parses a --since window: a Go duration such as 24h, or a number
+// of days such as 7d
+func parseSince(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if days, ok := strings.CutSuffix(value, "d"); ok && err != nil {
+		var n float64
+		if n, err = strconv.ParseFloat(days, 64); err == nil {
+			d = time.Duration(n * float64(24*time.Hour))
+		}
+	}
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid --since %q: use a positive duration such as 24h or 7d", value)
+	}
+	return d, nil
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cuongtl1992/vibe-skills/internal/logging"
+	"github.com/spf13/cobra"
+)
+
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove installed skills that the registry no longer provides",
+	Long: `Remove installed skills that were installed from the registry but are no
+longer listed in it. Skills that are not in the lockfile, such as ones written
+by hand, and skills installed from a local directory or Git URL are kept.
+
+The orphaned skills are listed and removed after confirmation. When stdin is
+not a terminal, pruning is aborted unless --yes is given.
+
+Examples:
+  vibe-skills prune
+  vibe-skills prune --global     # Prune ~/.claude/skills
+  vibe-skills prune --ref develop --yes`,
+	Args: cobra.NoArgs,
+	RunE: runPrune,
+}
+
+func runPrune(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	reg, err := getRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to create registry: %w", err)
+	}
+
+	inst := newInstaller(reg, cwd)
+
+	orphaned, err := inst.Orphaned()
+	if err != nil {
+		return err
+	}
+	if len(orphaned) == 0 {
+		logging.Infof("No orphaned skills in %s.\n", inst.SkillsDir())
+		return nil
+	}
+
+	logging.Infof("%d skill(s) are no longer in the registry (%s):\n", len(orphaned), reg.GetRef())
+	for _, name := range orphaned {
+		logging.Infof("  %s %s\n", markWarn(), name)
+	}
+
+	if !flagYes && !isTerminal(os.Stdin) {
+		return fmt.Errorf("%w: pass --yes to prune skills non-interactively", errAborted)
+	}
+	if !confirm(fmt.Sprintf("Remove %d skill(s)?", len(orphaned)), false) {
+		logging.Infof("Aborted.\n")
+		return nil
+	}
+
+	removed, errors := inst.RemoveMultiple(orphaned)
+
+	if len(removed) > 0 {
+		logging.Infof("Removed %d skill(s):\n", len(removed))
+		for _, name := range removed {
+			logging.Infof("  %s %s\n", markOK(), name)
+		}
+	}
+
+	if len(errors) > 0 {
+		fmt.Fprintf(textOut, "\nFailed to remove %d skill(s):\n", len(errors))
+		for _, err := range errors {
+			printFailure(err)
+		}
+		return newBatchError("some skills failed to remove", len(removed), errors)
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cuongtl1992/vibe-skills/internal/registry"
+	"github.com/spf13/cobra"
+)
+
+var registryCmd = &cobra.Command{
+	Use:   "registry",
+	Short: "Inspect the skill registry",
+	Long: `Inspect the skill registry the CLI reads from.
+
+Examples:
+  vibe-skills registry ping              # Check GitHub and any mirrors for the active ref
+  vibe-skills registry ping --ref v1.0.0`,
+}
+
+var registryPingCmd = &cobra.Command{
+	Use:   "ping",
+	Short: "Check that the registry index for the active ref can be fetched",
+	Long: `Request the registry index for the active ref from GitHub and from each
+configured mirror, and report the HTTP status, latency and whether the index
+parses. If the cache holds the index, the request revalidates it and reports
+whether the cached copy is current. The cache itself is left untouched.
+
+The hints tell apart network problems, missing access to a private
+registry, and a missing or malformed index.`,
+	Args: cobra.NoArgs,
+	RunE: runRegistryPing,
+}
+
+func init() {
+	registryCmd.AddCommand(registryPingCmd)
+}
+
+func runRegistryPing(cmd *cobra.Command, args []string) error {
+	reg, err := getRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to create registry: %w", err)
+	}
+
+	report, err := reg.Ping()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(textOut, "Registry: %s@%s\n", report.Repo, report.Ref)
+	switch {
+	case !report.Cached:
+		fmt.Fprintln(textOut, "Cache: no cached index")
+	case report.Fresh:
+		fmt.Fprintf(textOut, "Cache: index fetched %s ago (fresh)\n", time.Since(report.CachedAt).Round(time.Second))
+	default:
+		fmt.Fprintf(textOut, "Cache: index fetched %s ago (expired)\n", time.Since(report.CachedAt).Round(time.Second))
+	}
+
+	reachable, offline := 0, 0
+	for _, source := range report.Sources {
+		label := source.URL
+		if source.Mirror {
+			label = "mirror " + label
+		}
+		latency := source.Latency.Round(time.Millisecond)
+
+		switch {
+		case source.Err != nil:
+			if errors.Is(source.Err, registry.ErrNetwork) {
+				offline++
+			}
+			fmt.Fprintf(textOut, "  %s %s: %s (%s)\n", markFail(), label, source.Err, latency)
+			if hint := pingHint(source); hint != "" {
+				fmt.Fprintf(textOut, "      %s %s\n", markHint(), hint)
+			}
+		case source.NotModified:
+			reachable++
+			fmt.Fprintf(textOut, "  %s %s: HTTP %d in %s, cached index is current (%d skill(s))\n", markOK(), label, source.Status, latency, source.Skills)
+		default:
+			reachable++
+			fmt.Fprintf(textOut, "  %s %s: HTTP %d in %s, index parses (%d skill(s))\n", markOK(), label, source.Status, latency, source.Skills)
+		}
+	}
+
+	switch {
+	case reachable > 0:
+		return nil
+	case offline == len(report.Sources):
+		return fmt.Errorf("%w: no registry source could be reached", registry.ErrNetwork)
+	}
+	return fmt.Errorf("no registry source answered with a valid index")
+}
+
+// pingHint suggests what to check for a failed source
+func pingHint(source registry.PingResult) string {
+	switch {
+	case errors.Is(source.Err, registry.ErrNetwork):
+		return "network: check your connection, proxy or the mirror URL"
+	case source.Status == http.StatusUnauthorized, source.Status == http.StatusForbidden:
+		return "auth: check that " + registry.RegistryTokenEnv + " holds a valid token with read access"
+	case source.Status == http.StatusNotFound && !source.Mirror:
+		return "not found: check the --ref and repository, or set " + registry.RegistryTokenEnv + " for a private registry"
+	case source.Status == http.StatusNotFound:
+		return "not found: check that the mirror serves this ref"
+	case source.Status == http.StatusOK:
+		return "data: skills/registry.json at this ref is malformed"
+	}
+	return ""
+}
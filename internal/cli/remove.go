@@ -3,11 +3,14 @@ package cli
 import (
 	"fmt"
 	"os"
+	"strings"
 
-	"github.com/cuongtl1992/vibe-skills/internal/installer"
+	"github.com/cuongtl1992/vibe-skills/internal/logging"
 	"github.com/spf13/cobra"
 )
 
+var removeStack string
+
 var removeCmd = &cobra.Command{
 	Use:     "remove [skills...]",
 	Aliases: []string{"rm", "uninstall"},
@@ -16,11 +19,29 @@ var removeCmd = &cobra.Command{
 
 Examples:
   vibe-skills remove commit-convention
-  vibe-skills remove ef-core sql-optimization`,
-	Args: cobra.MinimumNArgs(1),
+  vibe-skills remove ef-core sql-optimization
+  vibe-skills remove --stack dotnet          # Remove every installed dotnet skill
+  vibe-skills remove --yes code-reviewer     # Skip the confirmation prompt
+
+A name that is not an installed directory also matches, if unambiguous, the
+installed skill with that name in another case or with _ for -, its
+stack/name, or the name declared in its SKILL.md.
+
+Removal asks for confirmation. When stdin is not a terminal it is aborted
+unless --yes is given.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if removeStack == "" && len(args) == 0 {
+			return fmt.Errorf("requires at least 1 skill name or --stack")
+		}
+		return nil
+	},
 	RunE: runRemove,
 }
 
+func init() {
+	removeCmd.Flags().StringVarP(&removeStack, "stack", "s", "", "Remove all installed skills from specified stack(s), comma-separated")
+}
+
 func runRemove(cmd *cobra.Command, args []string) error {
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -32,32 +53,54 @@ func runRemove(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create registry: %w", err)
 	}
 
-	inst := installer.New(reg, cwd)
+	inst := newInstaller(reg, cwd)
+
+	targets := args
+	if removeStack != "" {
+		targets = append([]string{"all skills in stack(s) " + removeStack}, args...)
+	}
+	if !flagYes && !isTerminal(os.Stdin) {
+		return fmt.Errorf("%w: pass --yes to remove skills non-interactively", errAborted)
+	}
+	if !confirm(fmt.Sprintf("Remove %s?", strings.Join(targets, ", ")), false) {
+		logging.Infof("Aborted.\n")
+		return nil
+	}
 
 	var removed []string
 	var errors []error
 
-	for _, name := range args {
-		if err := inst.Remove(name); err != nil {
-			errors = append(errors, fmt.Errorf("%s: %w", name, err))
-		} else {
-			removed = append(removed, name)
+	if removeStack != "" {
+		for _, stack := range strings.Split(removeStack, ",") {
+			r, e := inst.RemoveStack(strings.TrimSpace(stack))
+			removed = append(removed, r...)
+			errors = append(errors, e...)
 		}
 	}
 
+	if len(args) > 0 {
+		r, e := inst.RemoveMultiple(args)
+		removed = append(removed, r...)
+		errors = append(errors, e...)
+	}
+
 	if len(removed) > 0 {
-		fmt.Printf("Removed %d skill(s):\n", len(removed))
+		logging.Infof("Removed %d skill(s):\n", len(removed))
 		for _, name := range removed {
-			fmt.Printf("  ✓ %s\n", name)
+			logging.Infof("  %s %s\n", markOK(), name)
 		}
 	}
 
 	if len(errors) > 0 {
-		fmt.Printf("\nFailed to remove %d skill(s):\n", len(errors))
+		fmt.Fprintf(textOut, "\nFailed to remove %d skill(s):\n", len(errors))
 		for _, err := range errors {
-			fmt.Printf("  ✗ %s\n", err)
+			printFailure(err)
 		}
-		return fmt.Errorf("some skills failed to remove")
+		return newBatchError("some skills failed to remove", len(removed), errors)
+	}
+
+	if len(removed) == 0 {
+		logging.Infof("No skills to remove.\n")
 	}
 
 	return nil
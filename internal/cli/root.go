@@ -1,19 +1,34 @@
 package cli
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/cuongtl1992/vibe-skills/internal/config"
+	"github.com/cuongtl1992/vibe-skills/internal/installer"
+	"github.com/cuongtl1992/vibe-skills/internal/logging"
 	"github.com/cuongtl1992/vibe-skills/internal/registry"
+	"github.com/cuongtl1992/vibe-skills/internal/updater"
 	"github.com/spf13/cobra"
 )
 
 var (
 	// Global flags
-	flagBranch  string
-	flagRef     string
-	flagNoCache bool
+	flagBranch   string
+	flagRef      string
+	flagNoCache  bool
+	flagCacheTTL time.Duration
+	flagOffline  bool
+	flagTarget   string
+	flagGlobal   bool
+	flagQuiet    bool
+	flagVerbose  bool
+	flagDebug    bool
+	flagNoColor  bool
+	flagYes      bool
 )
 
 var rootCmd = &cobra.Command{
@@ -23,12 +38,32 @@ var rootCmd = &cobra.Command{
 
 Install and manage AI coding assistant skills organized by technology stack.
 Skills are installed to .claude/skills/ in your project directory.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		setLogLevel()
+		setColor()
+		// Best-effort: a binary replaced by self-update on Windows is deleted once it is no longer running
+		//nolint:errcheck
+		updater.CleanupOldExecutable()
+		if err := applyConfigDefaults(cmd); err != nil {
+			return err
+		}
+		startUpdateCheck(cmd)
+		return nil
+	},
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		printUpdateNotice()
+	},
 }
 
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		// Batch failures were printed with their hints already
+		var batch *batchError
+		if hint := errorHint(err); hint != "" && !errors.As(err, &batch) {
+			fmt.Fprintf(os.Stderr, "hint: %s\n", hint)
+		}
+		os.Exit(exitCode(err))
 	}
 }
 
@@ -36,7 +71,19 @@ func init() {
 	// Global flags for registry branch/ref
 	rootCmd.PersistentFlags().StringVar(&flagBranch, "branch", "", "Use skills from specific branch (e.g., develop)")
 	rootCmd.PersistentFlags().StringVar(&flagRef, "ref", "", "Use skills from specific ref (branch, tag, or commit)")
+	rootCmd.PersistentFlags().StringVar(&flagRef, "registry-ref", "", "Pin the registry to a ref (same as --ref; also "+config.RefEnvVar+")")
 	rootCmd.PersistentFlags().BoolVar(&flagNoCache, "no-cache", false, "Skip cache and fetch fresh from registry")
+	rootCmd.PersistentFlags().DurationVar(&flagCacheTTL, "cache-ttl", registry.DefaultCacheTTL, "How long the cached registry index is used before revalidating (0 to always revalidate)")
+	rootCmd.PersistentFlags().BoolVar(&flagOffline, "offline", false, "Use the cached registry index without contacting GitHub")
+	rootCmd.PersistentFlags().StringVar(&flagTarget, "target", installer.TargetDir, "Directory to install skills into, relative to the project")
+	rootCmd.PersistentFlags().BoolVarP(&flagGlobal, "global", "g", false, "Use skills installed in ~/.claude/skills instead of the project")
+	rootCmd.PersistentFlags().BoolVarP(&flagQuiet, "quiet", "q", false, "Only print errors")
+	rootCmd.PersistentFlags().BoolVarP(&flagVerbose, "verbose", "v", false, "Log HTTP requests, cache hits and file writes to stderr")
+	rootCmd.PersistentFlags().BoolVar(&flagDebug, "debug", false, "Log even more detail than --verbose")
+	rootCmd.PersistentFlags().BoolVar(&flagNoColor, "no-color", false, "Print plain ASCII markers without color (also NO_COLOR)")
+	rootCmd.PersistentFlags().BoolVarP(&flagYes, "yes", "y", false, "Do not ask for confirmation before removing or overwriting skills")
+	rootCmd.MarkFlagsMutuallyExclusive("quiet", "verbose")
+	rootCmd.MarkFlagsMutuallyExclusive("quiet", "debug")
 
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(installCmd)
@@ -46,28 +93,141 @@ func init() {
 	rootCmd.AddCommand(updateCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(selfUpdateCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(cacheCmd)
+	rootCmd.AddCommand(infoCmd)
+	rootCmd.AddCommand(outdatedCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(pruneCmd)
+	rootCmd.AddCommand(verifyCmd)
+	rootCmd.AddCommand(registryCmd)
+	rootCmd.AddCommand(migrateCmd)
 }
 
-// getRegistry creates a registry instance with resolved ref
-func getRegistry() (*registry.GitHubRegistry, error) {
-	cwd, err := os.Getwd()
-	if err != nil {
-		return nil, err
+// setLogLevel applies --quiet, --verbose and --debug
+func setLogLevel() {
+	switch {
+	case flagDebug:
+		logging.SetLevel(logging.LevelDebug)
+	case flagVerbose:
+		logging.SetLevel(logging.LevelVerbose)
+	case flagQuiet:
+		logging.SetLevel(logging.LevelQuiet)
+	default:
+		logging.SetLevel(logging.LevelNormal)
+	}
+}
+
+// applyConfigDefaults sets --target and --cache-ttl from the config files
+// unless they were given on the command line. The registry ref is resolved
+// later, by registryOptions.
+func applyConfigDefaults(cmd *cobra.Command) error {
+	projectCfg, globalCfg := loadConfigs()
+
+	if !cmd.Flags().Changed("target") {
+		if target := config.ResolveTarget(projectCfg, globalCfg); target != "" {
+			flagTarget = target
+		}
 	}
 
-	// Load configs
+	if !cmd.Flags().Changed("cache-ttl") {
+		ttl, ok, err := config.ResolveCacheTTL(projectCfg, globalCfg)
+		if err != nil {
+			return err
+		}
+		if ok {
+			flagCacheTTL = ttl
+		}
+	}
+	return nil
+}
+
+// loadConfigs loads the project config in the current directory and the
+// global config. Missing or unreadable files are returned as nil.
+func loadConfigs() (*config.Config, *config.GlobalConfig) {
 	var projectCfg *config.Config
-	if config.Exists(cwd) {
+	if cwd, err := os.Getwd(); err == nil && config.Exists(cwd) {
 		projectCfg, _ = config.Load(cwd)
 	}
 
 	globalCfg, _ := config.LoadGlobal()
+	return projectCfg, globalCfg
+}
+
+// getRegistry creates a registry instance with resolved ref
+func getRegistry() (*registry.GitHubRegistry, error) {
+	opts, err := registryOptions()
+	if err != nil {
+		return nil, err
+	}
+	return registry.NewGitHubRegistry(opts), nil
+}
+
+// registryOptions resolves the registry settings from flags and config files
+func registryOptions() (*registry.GitHubRegistryOptions, error) {
+	projectCfg, globalCfg := loadConfigs()
 
 	// Resolve ref with priority
 	ref := config.ResolveRef(flagBranch, flagRef, projectCfg, globalCfg)
+	if err := registry.ValidateRef(ref); err != nil {
+		return nil, err
+	}
+
+	owner, repo := config.ResolveRepository(projectCfg, globalCfg)
+
+	// A variable named in the global config wins over the standard ones
+	token := os.Getenv(registry.RegistryTokenEnv)
+	if name := config.ResolveTokenEnv(globalCfg); name != "" {
+		if value := os.Getenv(name); value != "" {
+			token = value
+		}
+	}
+
+	return &registry.GitHubRegistryOptions{
+		Owner:    owner,
+		Repo:     repo,
+		Ref:      ref,
+		Token:    token,
+		NoCache:  flagNoCache,
+		Cache:    registry.NewCacheWithTTL(flagCacheTTL),
+		Offline:  flagOffline,
+		OnStale:  warnStale,
+		Mirrors:  config.ResolveMirrors(projectCfg, globalCfg),
+		OnMirror: warnMirror,
+	}, nil
+}
 
-	return registry.NewGitHubRegistry(&registry.GitHubRegistryOptions{
-		Ref:     ref,
-		NoCache: flagNoCache,
-	}), nil
+// printJSON writes v to stdout as indented JSON
+func printJSON(v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// warnStale tells the user the registry index being used may be out of date
+func warnStale(fetchedAt time.Time, err error) {
+	age := time.Since(fetchedAt).Round(time.Minute)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: registry unreachable (%v), using cached index from %s ago\n", err, age)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Warning: offline, using cached index from %s ago\n", age)
+}
+
+// warnMirror tells the user the registry is being served by a mirror
+func warnMirror(mirror string, err error) {
+	fmt.Fprintf(os.Stderr, "Warning: registry unreachable (%v), using mirror %s\n", err, mirror)
+}
+
+// newInstaller creates an installer for the project in cwd honoring --target and --global
+func newInstaller(provider installer.SkillProvider, cwd string) *installer.Installer {
+	inst := installer.NewWithTarget(provider, cwd, flagTarget)
+	if flagGlobal {
+		inst.SetScope(installer.ScopeGlobal)
+	}
+	return inst
 }
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cuongtl1992/vibe-skills/internal/config"
+	"github.com/cuongtl1992/vibe-skills/internal/registry"
+)
+
+func TestRegistryOptionsTokenEnv(t *testing.T) {
+	root := t.TempDir()
+	home := filepath.Join(root, "home")
+	project := filepath.Join(root, "project")
+	if err := os.MkdirAll(project, 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HOME", home)
+	t.Setenv(registry.RegistryTokenEnv, "")
+	t.Setenv("PROJECT_SECRET", "leaked")
+	t.Setenv("GLOBAL_TOKEN", "global")
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(project); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	projectCfg := config.GetDefaultConfig()
+	projectCfg.Registry = &config.RegistryConfig{Repository: "evil/skills", TokenEnv: "PROJECT_SECRET"}
+	if err := config.Save(project, projectCfg); err != nil {
+		t.Fatal(err)
+	}
+
+	opts, err := registryOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.Token != "" {
+		t.Errorf("token_env in the project config sent %q, want no token", opts.Token)
+	}
+
+	if err := config.SaveGlobal(&config.GlobalConfig{Registry: &config.RegistryConfig{TokenEnv: "GLOBAL_TOKEN"}}); err != nil {
+		t.Fatal(err)
+	}
+	opts, err = registryOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.Token != "global" {
+		t.Errorf("token_env in the global config gave token %q, want %q", opts.Token, "global")
+	}
+}
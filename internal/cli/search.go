@@ -4,18 +4,19 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/cuongtl1992/vibe-skills/internal/installer"
 	"github.com/spf13/cobra"
 )
 
 var searchCmd = &cobra.Command{
 	Use:   "search [query]",
 	Short: "Search for skills",
-	Long: `Search for skills by name or description.
+	Long: `Search for skills by name, description or stack.
+Results are ranked by relevance and tolerate small typos.
 
 Examples:
   vibe-skills search database
-  vibe-skills search "code review"`,
+  vibe-skills search "code review"
+  vibe-skills search reviwer`,
 	Args: cobra.ExactArgs(1),
 	RunE: runSearch,
 }
@@ -33,28 +34,28 @@ func runSearch(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create registry: %w", err)
 	}
 
-	inst := installer.New(reg, cwd)
+	inst := newInstaller(reg, cwd)
 
 	results, err := reg.Search(query)
 	if err != nil {
 		return fmt.Errorf("failed to search skills: %w", err)
 	}
 	if len(results) == 0 {
-		fmt.Printf("No skills found matching: %s\n", query)
+		fmt.Fprintf(textOut, "No skills found matching: %s\n", query)
 		return nil
 	}
 
-	fmt.Printf("Found %d skill(s) matching '%s':\n\n", len(results), query)
+	fmt.Fprintf(textOut, "Found %d skill(s) matching '%s':\n\n", len(results), query)
 	for _, skill := range results {
 		installed := ""
 		if inst.IsInstalled(skill.Name) {
 			installed = " [installed]"
 		}
-		fmt.Printf("  %s/%s%s\n", skill.Stack, skill.Name, installed)
+		fmt.Fprintf(textOut, "  %s/%s%s\n", skill.Stack, skill.Name, installed)
 		if skill.Description != "" {
-			fmt.Printf("    %s\n", skill.Description)
+			fmt.Fprintf(textOut, "    %s\n", skill.Description)
 		}
-		fmt.Println()
+		fmt.Fprintln(textOut)
 	}
 
 	return nil
@@ -1,41 +1,135 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
 
 	"github.com/cuongtl1992/vibe-skills/internal/updater"
 	"github.com/cuongtl1992/vibe-skills/internal/version"
 	"github.com/spf13/cobra"
 )
 
+// updateCheckTimeout bounds how long looking up release information may take
+const updateCheckTimeout = 30 * time.Second
+
+var (
+	selfUpdateRollback bool
+	selfUpdateVersion  string
+	selfUpdateChannel  string
+	selfUpdatePre      bool
+)
+
 var selfUpdateCmd = &cobra.Command{
 	Use:   "self-update",
 	Short: "Update vibe-skills to the latest version",
-	Long:  `Downloads and installs the latest version of vibe-skills from GitHub releases.`,
-	RunE:  runSelfUpdate,
+	Long: `Downloads and installs the latest version of vibe-skills from GitHub releases.
+
+Examples:
+  vibe-skills self-update                    # Update to the latest release
+  vibe-skills self-update --version v0.2.0   # Install a specific release
+  vibe-skills self-update --channel beta     # Include beta pre-releases
+  vibe-skills self-update --prerelease       # Install the highest release, pre-releases included
+  vibe-skills self-update --rollback         # Restore the previous binary
+
+Each update keeps the binary it replaced next to the executable with a .bak
+suffix until the next update, so --rollback can bring it back.`,
+	RunE: runSelfUpdate,
+}
+
+func init() {
+	selfUpdateCmd.Flags().BoolVar(&selfUpdateRollback, "rollback", false, "Restore the binary backed up by the previous update")
+	selfUpdateCmd.Flags().StringVar(&selfUpdateVersion, "version", "", "Install a specific release tag (supports downgrades)")
+	selfUpdateCmd.Flags().StringVar(&selfUpdateChannel, "channel", updater.ChannelStable, "Release channel: stable, beta, or nightly")
+	selfUpdateCmd.Flags().BoolVar(&selfUpdatePre, "prerelease", false, "Consider every release, including pre-releases, and install the highest version")
 }
 
 func runSelfUpdate(cmd *cobra.Command, args []string) error {
-	fmt.Printf("Current version: %s\n", version.GetVersion())
-	fmt.Println("Checking for updates...")
+	if selfUpdatePre && cmd.Flags().Changed("channel") {
+		return fmt.Errorf("--prerelease cannot be combined with --channel")
+	}
+
+	if selfUpdateRollback {
+		if err := updater.RollbackUpdate(); err != nil {
+			return fmt.Errorf("failed to roll back: %w", err)
+		}
+		fmt.Fprintln(textOut, "Restored previous version.")
+		return nil
+	}
+
+	// Cancel in-flight requests cleanly on Ctrl-C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Fprintf(textOut, "Current version: %s\n", version.GetVersion())
 
-	latestVersion, hasUpdate, err := updater.CheckForUpdate()
+	if selfUpdateVersion != "" {
+		fmt.Fprintf(textOut, "Downloading version %s...\n", selfUpdateVersion)
+		err := updater.SelfUpdateToVersionContext(ctx, selfUpdateVersion, &updater.UpdateOptions{Progress: printProgress})
+		fmt.Fprintln(textOut)
+		if err != nil {
+			return fmt.Errorf("failed to update: %w", err)
+		}
+		fmt.Fprintf(textOut, "Successfully installed version %s\n", selfUpdateVersion)
+		return nil
+	}
+
+	fmt.Fprintln(textOut, "Checking for updates...")
+
+	checkCtx, cancel := context.WithTimeout(ctx, updateCheckTimeout)
+	defer cancel()
+
+	check, err := updater.CheckForUpdateWithOptions(checkCtx, &updater.CheckOptions{
+		Channel:    selfUpdateChannel,
+		Prerelease: selfUpdatePre,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to check for updates: %w", err)
 	}
 
-	if !hasUpdate {
-		fmt.Println("You are already running the latest version.")
+	if !check.HasUpdate {
+		fmt.Fprintln(textOut, "You are already running the latest version.")
 		return nil
 	}
 
-	fmt.Printf("New version available: %s\n", latestVersion)
-	fmt.Println("Downloading update...")
+	latestVersion := check.Version
+	if check.Prerelease {
+		latestVersion += " (prerelease)"
+	}
+	fmt.Fprintf(textOut, "New version available: %s\n", latestVersion)
+	fmt.Fprintln(textOut, "Downloading update...")
 
-	if err := updater.SelfUpdate(); err != nil {
+	err = updater.SelfUpdateContext(ctx, &updater.UpdateOptions{
+		Channel:    selfUpdateChannel,
+		Prerelease: selfUpdatePre,
+		Progress:   printProgress,
+	})
+	fmt.Fprintln(textOut)
+	if err != nil {
 		return fmt.Errorf("failed to update: %w", err)
 	}
 
-	fmt.Printf("Successfully updated to version %s\n", latestVersion)
+	fmt.Fprintf(textOut, "Successfully updated to version %s\n", latestVersion)
 	return nil
 }
+
+// printProgress renders a single-line download progress bar
+func printProgress(downloaded, total int64) {
+	const width = 30
+
+	if total <= 0 {
+		fmt.Fprintf(textOut, "\r  %.1f MB downloaded", float64(downloaded)/(1<<20))
+		return
+	}
+
+	filled := int(downloaded * width / total)
+	if filled > width {
+		filled = width
+	}
+	fmt.Fprintf(textOut, "\r  [%s%s] %3d%% (%.1f/%.1f MB)",
+		strings.Repeat("=", filled), strings.Repeat(" ", width-filled),
+		downloaded*100/total, float64(downloaded)/(1<<20), float64(total)/(1<<20))
+}
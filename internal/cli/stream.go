@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/cuongtl1992/vibe-skills/internal/installer"
+	"github.com/cuongtl1992/vibe-skills/internal/logging"
+)
+
+// Values of --output
+const (
+	outputText       = "text"
+	outputJSONStream = "json-stream"
+)
+
+// streamEvent is one line of --output json-stream
+type streamEvent struct {
+	Skill  string `json:"skill,omitempty"`
+	Event  string `json:"event"`
+	Path   string `json:"path,omitempty"`   // File being written, or hook script run
+	Output string `json:"output,omitempty"` // Hook output
+	Error  string `json:"error,omitempty"`
+
+	// Set on the final "summary" event
+	Installed []string `json:"installed,omitempty"`
+	Updated   []string `json:"updated,omitempty"`
+	Current   []string `json:"current,omitempty"`
+	Skipped   []string `json:"skipped,omitempty"`
+	Errors    []string `json:"errors,omitempty"`
+}
+
+// eventStream writes newline-delimited JSON events to what was stdout
+type eventStream struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// stream is the active event stream, nil unless --output json-stream is set
+var stream *eventStream
+
+// textOut receives the human-readable output of every command, JSON excepted;
+// it is stderr while an event stream owns stdout
+var textOut io.Writer = os.Stdout
+
+// startOutput sets up the output mode; for json-stream, stdout is kept for
+// the events and human-readable output moves to stderr. The returned function
+// restores the text output.
+func startOutput(mode string) (func(), error) {
+	switch mode {
+	case outputText:
+		return func() {}, nil
+	case outputJSONStream:
+	default:
+		return nil, fmt.Errorf("invalid --output %q: expected %s or %s", mode, outputText, outputJSONStream)
+	}
+
+	stream = &eventStream{enc: json.NewEncoder(os.Stdout)}
+	textOut = os.Stderr
+	logging.SetOutput(os.Stderr, os.Stderr)
+	return func() {
+		stream = nil
+		textOut = os.Stdout
+		logging.SetOutput(os.Stdout, os.Stderr)
+	}, nil
+}
+
+// streamProgress writes the installer's progress events to the event stream,
+// hook output included. It replaces any earlier OnProgress callback, so it is
+// called after enableHooks.
+func streamProgress(inst *installer.Installer) {
+	if stream == nil {
+		return
+	}
+	inst.OnProgress(func(event installer.InstallEvent) {
+		e := streamEvent{Skill: event.Skill, Event: string(event.Phase), Path: event.Path, Output: event.Output}
+		if event.Err != nil {
+			e.Error = event.Err.Error()
+		}
+		stream.write(e)
+	})
+}
+
+// write emits one event; the stream is left alone once stdout fails
+func (s *eventStream) write(event streamEvent) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.enc.Encode(event); err != nil {
+		s.enc = json.NewEncoder(io.Discard)
+	}
+}
+
+// errorStrings renders errs for a summary event
+func errorStrings(errs []error) []string {
+	var result []string
+	for _, err := range errs {
+		result = append(result, err.Error())
+	}
+	return result
+}
@@ -0,0 +1,18 @@
+//go:build windows
+
+package cli
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+var procGetConsoleMode = syscall.NewLazyDLL("kernel32.dll").NewProc("GetConsoleMode")
+
+// isTerminal reports whether f is a console, as opposed to a file or pipe
+func isTerminal(f *os.File) bool {
+	var mode uint32
+	r, _, _ := procGetConsoleMode.Call(f.Fd(), uintptr(unsafe.Pointer(&mode)))
+	return r != 0
+}
@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// timeoutContext returns a context that expires after timeout, or never when
+// timeout is 0
+func timeoutContext(timeout time.Duration) (context.Context, context.CancelFunc, error) {
+	switch {
+	case timeout < 0:
+		return nil, nil, fmt.Errorf("invalid --timeout %s: must not be negative", timeout)
+	case timeout == 0:
+		ctx, cancel := context.WithCancel(context.Background())
+		return ctx, cancel, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	return ctx, cancel, nil
+}
+
+// printTimeout notes that a batch stopped at its --timeout
+func printTimeout(ctx context.Context, timeout time.Duration) {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		fmt.Fprintf(textOut, "\n%s Timed out after %s; skills not started were not processed\n", markFail(), timeout)
+	}
+}
@@ -1,10 +1,14 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/cuongtl1992/vibe-skills/internal/installer"
+	"github.com/cuongtl1992/vibe-skills/internal/logging"
 	"github.com/spf13/cobra"
 )
 
@@ -12,6 +16,9 @@ var updateCmd = &cobra.Command{
 	Use:   "update [skill-names...]",
 	Short: "Update installed skills to latest version",
 	Long: `Update installed skills to their latest version from the registry.
+Skills whose files have not changed in the registry are reported as up to
+date and left untouched. Skills committed to the project's Git repository get
+a warning when the update changes their tracked files.
 
 Examples:
   # Update all installed skills
@@ -19,11 +26,54 @@ Examples:
 
   # Update specific skill(s)
   vibe-skills update code-reviewer
-  vibe-skills update code-reviewer sqlserver-expert`,
+  vibe-skills update code-reviewer sqlserver-expert
+
+  # Overwrite skills even if they were edited locally (asks first unless --yes)
+  vibe-skills update --force
+
+  # Show which skills and files would change, without updating
+  vibe-skills update --dry-run
+
+  # Update up to 8 skills at once (default 4)
+  vibe-skills update -j 8
+
+  # Keep updating whenever the registry index changes, until Ctrl-C
+  vibe-skills update --watch --interval 10m
+
+  # Print progress as JSON lines on stdout, ending with a summary event
+  vibe-skills update --output json-stream`,
 	RunE: runUpdate,
 }
 
+var (
+	updateForce  bool
+	updateHooks  bool
+	updateDryRun bool
+	updateWatch  bool
+	updateEvery  time.Duration
+	updateTime   time.Duration
+	updateJobs   int
+	updateOutput string
+)
+
+func init() {
+	updateCmd.Flags().BoolVarP(&updateForce, "force", "f", false, "Overwrite skills that have local modifications")
+	updateCmd.Flags().BoolVar(&updateHooks, "run-hooks", false, "Run pre-install and post-install hook scripts shipped with skills")
+	updateCmd.Flags().BoolVar(&updateDryRun, "dry-run", false, "Show the skills and files that would change without updating")
+	updateCmd.Flags().BoolVar(&updateWatch, "watch", false, "Keep running and update again whenever the registry index changes")
+	updateCmd.Flags().DurationVar(&updateTime, "timeout", 0, "Stop starting new skill updates after this long, e.g. 2m (0 for no limit)")
+	updateCmd.Flags().DurationVar(&updateEvery, "interval", defaultWatchInterval, "How often --watch checks the registry")
+	updateCmd.Flags().IntVarP(&updateJobs, "parallel", "j", installer.DefaultMaxParallel, "Number of skills to update concurrently")
+	updateCmd.Flags().StringVar(&updateOutput, "output", outputText, "Output format: text, or json-stream for one JSON event per line on stdout")
+}
+
 func runUpdate(cmd *cobra.Command, args []string) error {
+	restoreOutput, err := startOutput(updateOutput)
+	if err != nil {
+		return err
+	}
+	defer restoreOutput()
+
 	reg, err := getRegistry()
 	if err != nil {
 		return err
@@ -34,12 +84,51 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get current directory: %w", err)
 	}
 
-	inst := installer.New(reg, cwd)
+	logging.Infof("Using registry: %s\n\n", reg.GetRef())
+
+	inst := newInstaller(reg, cwd)
+	inst.SetMaxParallel(updateJobs)
+	enableHooks(inst, updateHooks)
+	streamProgress(inst)
+
+	if updateDryRun {
+		return runUpdateDryRun(inst, args)
+	}
+
+	if updateTime < 0 {
+		return fmt.Errorf("invalid --timeout %s: must not be negative", updateTime)
+	}
+	if updateWatch && updateEvery <= 0 {
+		return fmt.Errorf("invalid --interval %s: must be positive", updateEvery)
+	}
+
+	if updateForce && !confirmOverwrite(inst, args) {
+		logging.Infof("Aborted.\n")
+		return nil
+	}
+
+	err = updateSkills(inst, args)
+	if !updateWatch {
+		return err
+	}
+	return watchRegistry(reg, inst, args)
+}
+
+// updateSkills updates the named skills, or all installed skills when names
+// is empty, within --timeout and prints the outcome of each
+func updateSkills(inst *installer.Installer, names []string) error {
+	ctx, cancel, err := timeoutContext(updateTime)
+	if err != nil {
+		return err
+	}
+	defer cancel()
 
 	var updated []string
-	var errors []error
+	var current []string
+	var skipped []string
+	var failures []error
 
-	if len(args) == 0 {
+	if len(names) == 0 {
 		// Update all installed skills
 		installed, err := inst.ListInstalled()
 		if err != nil {
@@ -47,38 +136,152 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		}
 
 		if len(installed) == 0 {
-			fmt.Println("No skills installed to update")
+			logging.Infof("No skills installed to update\n")
 			return nil
 		}
 
-		fmt.Printf("Updating %d installed skill(s)...\n", len(installed))
-		updated, errors = inst.UpdateAll()
+		logging.Infof("Updating %d installed skill(s)...\n", len(installed))
+		updated, current, skipped, failures = inst.UpdateAllContext(ctx, updateForce)
 	} else {
 		// Update specific skills
-		fmt.Printf("Updating %d skill(s)...\n", len(args))
-		for _, name := range args {
-			if err := inst.Update(name); err != nil {
-				errors = append(errors, fmt.Errorf("%s: %w", name, err))
-			} else {
+		logging.Infof("Updating %d skill(s)...\n", len(names))
+		for _, name := range names {
+			if ctx.Err() != nil {
+				failures = append(failures, fmt.Errorf("%s: not started: %w", name, ctx.Err()))
+				continue
+			}
+			err := inst.Update(name, updateForce)
+			switch {
+			case errors.Is(err, installer.ErrUpToDate):
+				current = append(current, name)
+			case errors.Is(err, installer.ErrLocallyModified):
+				skipped = append(skipped, name)
+			case err != nil:
+				failures = append(failures, fmt.Errorf("%s: %w", name, err))
+			default:
 				updated = append(updated, name)
 			}
 		}
 	}
 
+	stream.write(streamEvent{
+		Event:   "summary",
+		Updated: updated,
+		Current: current,
+		Skipped: skipped,
+		Errors:  errorStrings(failures),
+	})
+
 	// Print results
 	for _, name := range updated {
-		fmt.Printf("  ✓ %s\n", name)
+		logging.Infof("  %s %s\n", markOK(), name)
 	}
-	for _, err := range errors {
-		fmt.Printf("  ✗ %s\n", err)
+	warnGitTracked(inst, updated)
+	for _, name := range current {
+		logging.Infof("  %s %s: up to date\n", markOK(), name)
 	}
+	for _, name := range skipped {
+		logging.Infof("  %s %s: skipped, has local modifications (use --force to overwrite)\n", markWarn(), name)
+	}
+	for _, err := range failures {
+		printFailure(err)
+	}
+	printTimeout(ctx, updateTime)
 
-	if len(errors) > 0 {
-		return fmt.Errorf("failed to update %d skill(s)", len(errors))
+	if len(failures) > 0 {
+		return newBatchError(fmt.Sprintf("failed to update %d skill(s)", len(failures)), len(updated), failures)
 	}
 
-	if len(updated) > 0 {
-		fmt.Printf("\nUpdated %d skill(s)\n", len(updated))
+	switch {
+	case len(updated) > 0:
+		logging.Infof("\nUpdated %d skill(s)\n", len(updated))
+	case len(current) > 0 && len(skipped) == 0:
+		logging.Infof("\nAll skills are up to date\n")
 	}
 	return nil
 }
+
+// runUpdateDryRun prints the skills an update would change and their changed
+// files, as compared by the outdated command
+func runUpdateDryRun(inst *installer.Installer, names []string) error {
+	if len(names) == 0 {
+		installed, err := inst.ListInstalled()
+		if err != nil {
+			return fmt.Errorf("failed to list installed skills: %w", err)
+		}
+		if len(installed) == 0 {
+			fmt.Fprintln(textOut, "No skills installed to update")
+			return nil
+		}
+		names = installed
+	}
+
+	var changing int
+	var failures []error
+	for _, name := range names {
+		outdated, err := inst.CheckOutdated(name)
+		if err != nil {
+			failures = append(failures, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		modified, err := inst.IsModified(name)
+		if err != nil {
+			failures = append(failures, fmt.Errorf("%s: failed to check for local changes: %w", name, err))
+			continue
+		}
+
+		switch {
+		case modified && !updateForce:
+			fmt.Fprintf(textOut, "  %s %s: would be skipped, has local modifications (use --force to overwrite)\n", markWarn(), name)
+			continue
+		case outdated == nil && !modified:
+			fmt.Fprintf(textOut, "  %s %s: up to date\n", markOK(), name)
+			continue
+		}
+
+		diffs, err := inst.Diff(name)
+		if err != nil {
+			failures = append(failures, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		changing++
+		fmt.Fprintf(textOut, "%s:\n", name)
+		for _, diff := range diffs {
+			fmt.Fprintf(textOut, "  %-9s %s\n", diff.Status, diff.Path)
+		}
+		if tracked, err := inst.TrackedFiles(name); err == nil && len(tracked) > 0 {
+			fmt.Fprintf(textOut, "  %s tracked by Git: the update will show up in git diff\n", markHint())
+		}
+	}
+
+	for _, err := range failures {
+		printFailure(err)
+	}
+	if len(failures) > 0 {
+		return newBatchError(fmt.Sprintf("failed to check %d skill(s)", len(failures)), len(names)-len(failures), failures)
+	}
+
+	fmt.Fprintf(textOut, "\n%d skill(s) would be updated\n", changing)
+	return nil
+}
+
+// confirmOverwrite asks before --force discards local changes to the named
+// skills, or to all installed skills when names is empty. Scripts without a
+// terminal proceed, as they asked for --force explicitly.
+func confirmOverwrite(inst *installer.Installer, names []string) bool {
+	if len(names) == 0 {
+		names, _ = inst.ListInstalled()
+	}
+
+	var modified []string
+	for _, name := range names {
+		if changed, err := inst.IsModified(name); err == nil && changed {
+			modified = append(modified, name)
+		}
+	}
+	if len(modified) == 0 {
+		return true
+	}
+
+	return confirm(fmt.Sprintf("Overwrite local changes to %s?", strings.Join(modified, ", ")), true)
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/cuongtl1992/vibe-skills/internal/installer"
+	"github.com/cuongtl1992/vibe-skills/internal/logging"
+	"github.com/spf13/cobra"
+)
+
+var verifyJSON bool
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check that installed skills exactly match the lockfile",
+	Long: `Check that the skills directory matches its lockfile: every locked skill
+is installed with the same files and content hashes, and no other skills or
+files are present. Nothing is modified and the registry is not contacted.
+
+The differences are printed and the command exits non-zero if there are any,
+so it can gate CI on an up-to-date, untampered skills directory.
+
+Examples:
+  vibe-skills verify
+  vibe-skills verify --global
+  vibe-skills verify --json`,
+	Args: cobra.NoArgs,
+	RunE: runVerify,
+}
+
+func init() {
+	verifyCmd.Flags().BoolVar(&verifyJSON, "json", false, "Print the differences as JSON")
+}
+
+// verifyDrift is the JSON form of a difference from the lockfile
+type verifyDrift struct {
+	Skill  string `json:"skill"`
+	Path   string `json:"path,omitempty"`
+	Status string `json:"status"`
+}
+
+func runVerify(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	inst := newInstaller(nil, cwd)
+
+	drift, err := inst.CheckLock()
+	if err != nil {
+		return err
+	}
+
+	if verifyJSON {
+		result := make([]verifyDrift, 0, len(drift))
+		for _, d := range drift {
+			result = append(result, verifyDrift{Skill: d.Skill, Path: d.Path, Status: string(d.Status)})
+		}
+		if err := printJSON(result); err != nil {
+			return err
+		}
+	} else if len(drift) > 0 {
+		fmt.Fprintf(textOut, "%s differs from its lockfile:\n", inst.SkillsDir())
+		for _, d := range drift {
+			fmt.Fprintf(textOut, "  %s %-8s %s\n", driftMark(d.Status), d.Status, path.Join(d.Skill, d.Path))
+		}
+	}
+
+	if len(drift) > 0 {
+		return fmt.Errorf("%d difference(s) from the lockfile", len(drift))
+	}
+
+	lock, err := inst.ReadLock()
+	if err != nil {
+		return fmt.Errorf("failed to read lockfile: %w", err)
+	}
+	logging.Infof("%s %d skill(s) match the lockfile\n", markOK(), len(lock.Skills))
+	return nil
+}
+
+func driftMark(status installer.DriftStatus) string {
+	if status == installer.DriftModified {
+		return markWarn()
+	}
+	return markFail()
+}
@@ -1,16 +1,75 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	"time"
 
+	"github.com/cuongtl1992/vibe-skills/internal/updater"
 	"github.com/cuongtl1992/vibe-skills/internal/version"
 	"github.com/spf13/cobra"
 )
 
+// versionUpdateTimeout bounds the best-effort release check
+const versionUpdateTimeout = 3 * time.Second
+
+var versionJSON bool
+
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print version information",
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("vibe-skills %s\n", version.GetFullVersion())
-	},
+	Long: `Print the version, commit, build date, Go version and platform of this
+binary, and whether a newer release is available.
+
+Examples:
+  vibe-skills version
+  vibe-skills version --json`,
+	Args: cobra.NoArgs,
+	RunE: runVersion,
+}
+
+func init() {
+	versionCmd.Flags().BoolVar(&versionJSON, "json", false, "Print version information as JSON")
+}
+
+// versionInfo is the build metadata printed by version --json
+type versionInfo struct {
+	version.Info
+	UpdateAvailable string `json:"update_available,omitempty"`
+}
+
+func runVersion(cmd *cobra.Command, args []string) error {
+	info := versionInfo{Info: version.GetInfo(), UpdateAvailable: availableUpdate()}
+
+	if versionJSON {
+		return printJSON(info)
+	}
+
+	update := ""
+	if info.UpdateAvailable != "" {
+		update = fmt.Sprintf(" (update available: %s)", info.UpdateAvailable)
+	}
+	fmt.Fprintf(textOut, "vibe-skills %s%s\n", info.Version, update)
+	fmt.Fprintf(textOut, "  commit:  %s\n", info.Commit)
+	fmt.Fprintf(textOut, "  built:   %s\n", info.Date)
+	fmt.Fprintf(textOut, "  go:      %s\n", info.GoVersion)
+	fmt.Fprintf(textOut, "  os/arch: %s/%s\n", info.OS, info.Arch)
+	return nil
+}
+
+// availableUpdate returns the newer stable release, or "" when there is none,
+// the binary is a development build, or the check fails
+func availableUpdate() string {
+	if _, err := version.ParseSemver(version.GetVersion()); err != nil {
+		return ""
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), versionUpdateTimeout)
+	defer cancel()
+
+	latest, hasUpdate, err := updater.CheckForUpdateContext(ctx, updater.ChannelStable)
+	if err != nil || !hasUpdate {
+		return ""
+	}
+	return latest
 }
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"syscall"
+	"time"
+
+	"github.com/cuongtl1992/vibe-skills/internal/installer"
+	"github.com/cuongtl1992/vibe-skills/internal/logging"
+	"github.com/cuongtl1992/vibe-skills/internal/registry"
+)
+
+// defaultWatchInterval is how often update --watch checks the registry
+const defaultWatchInterval = 5 * time.Minute
+
+// watchRegistry checks the registry index every updateEvery and updates the
+// named skills, or all installed ones, each time it changes. Failures are
+// printed and the watch goes on until interrupted.
+func watchRegistry(reg *registry.GitHubRegistry, inst *installer.Installer, names []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	previous, err := reg.List()
+	if err != nil {
+		return fmt.Errorf("failed to list skills: %w", err)
+	}
+
+	logging.Infof("\nWatching %s every %s (Ctrl-C to stop)...\n", reg.GetRef(), updateEvery)
+	ticker := time.NewTicker(updateEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logging.Infof("\nStopped watching.\n")
+			return nil
+		case <-ticker.C:
+		}
+
+		index, changed, err := reg.Refresh()
+		if err != nil {
+			printFailure(fmt.Errorf("failed to check registry: %w", err))
+			continue
+		}
+		if !changed {
+			logging.Verbosef("registry %s unchanged", reg.GetRef())
+			continue
+		}
+
+		logging.Infof("\n[%s] Registry %s changed:\n", time.Now().Format("15:04:05"), reg.GetRef())
+		for _, line := range indexChanges(previous, index.Skills) {
+			logging.Infof("  %s\n", line)
+		}
+		previous = index.Skills
+
+		var batch *batchError
+		if err := updateSkills(inst, names); err != nil && !errors.As(err, &batch) {
+			printFailure(err)
+		}
+	}
+}
+
+// indexChanges describes the skills added to, removed from or changed in the
+// registry index between two versions of it
+func indexChanges(before, after []registry.Skill) []string {
+	old := make(map[string]registry.Skill, len(before))
+	for _, skill := range before {
+		old[skill.Name] = skill
+	}
+
+	var lines []string
+	for _, skill := range after {
+		prev, ok := old[skill.Name]
+		delete(old, skill.Name)
+		switch {
+		case !ok:
+			lines = append(lines, fmt.Sprintf("added    %s", skill.Name))
+		case !reflect.DeepEqual(prev, skill):
+			lines = append(lines, fmt.Sprintf("changed  %s", skill.Name))
+		}
+	}
+	for _, skill := range before {
+		if _, ok := old[skill.Name]; ok {
+			lines = append(lines, fmt.Sprintf("removed  %s", skill.Name))
+		}
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "no skill entries changed")
+	}
+	return lines
+}
@@ -1,8 +1,11 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -11,23 +14,42 @@ const (
 	ConfigFileName       = ".vibe-skills.yaml"
 	GlobalConfigDir      = ".vibe-skills"
 	GlobalConfigFileName = "config.yaml"
+
+	// RefEnvVar pins the registry ref for every command, below the CLI flags
+	RefEnvVar = "VIBE_SKILLS_REF"
 )
 
 // RegistryConfig holds registry-specific configuration
 type RegistryConfig struct {
 	Branch string `yaml:"branch,omitempty"`
 	Ref    string `yaml:"ref,omitempty"`
+
+	// Repository is the GitHub "owner/repo" holding the registry
+	Repository string `yaml:"repository,omitempty"`
+
+	// TokenEnv names an environment variable holding a GitHub token for a
+	// private repository, so the token itself never lands in a config file.
+	// It is read from the global config only.
+	TokenEnv string `yaml:"token_env,omitempty"`
+
+	// Mirrors are tried in order when GitHub cannot be reached
+	Mirrors []string `yaml:"mirrors,omitempty"`
+
+	// CacheTTL is how long the cached index is used, as a duration such as "24h"
+	CacheTTL string `yaml:"cache_ttl,omitempty"`
 }
 
 // Config represents the project-level configuration
 type Config struct {
 	Registry *RegistryConfig `yaml:"registry,omitempty"`
+	Target   string          `yaml:"target,omitempty"` // Skills directory relative to the project
 	Skills   []string        `yaml:"skills"`
 }
 
 // GlobalConfig represents user-level configuration
 type GlobalConfig struct {
 	Registry *RegistryConfig `yaml:"registry,omitempty"`
+	Target   string          `yaml:"target,omitempty"` // Skills directory relative to each project
 }
 
 // Load loads project configuration from the specified directory
@@ -118,7 +140,7 @@ func SaveGlobal(cfg *GlobalConfig) error {
 	return os.WriteFile(path, data, 0644)
 }
 
-// ResolveRef resolves the registry ref with priority: flag > project > global > default
+// ResolveRef resolves the registry ref with priority: flag > env > project > global > default
 func ResolveRef(flagBranch, flagRef string, projectCfg *Config, globalCfg *GlobalConfig) string {
 	// Priority 1: CLI flags
 	if flagRef != "" {
@@ -128,7 +150,12 @@ func ResolveRef(flagBranch, flagRef string, projectCfg *Config, globalCfg *Globa
 		return flagBranch
 	}
 
-	// Priority 2: Project config
+	// Priority 2: Environment, so CI can pin the registry without touching config
+	if ref := os.Getenv(RefEnvVar); ref != "" {
+		return ref
+	}
+
+	// Priority 3: Project config
 	if projectCfg != nil && projectCfg.Registry != nil {
 		if projectCfg.Registry.Ref != "" {
 			return projectCfg.Registry.Ref
@@ -138,7 +165,7 @@ func ResolveRef(flagBranch, flagRef string, projectCfg *Config, globalCfg *Globa
 		}
 	}
 
-	// Priority 3: Global config
+	// Priority 4: Global config
 	if globalCfg != nil && globalCfg.Registry != nil {
 		if globalCfg.Registry.Ref != "" {
 			return globalCfg.Registry.Ref
@@ -148,6 +175,75 @@ func ResolveRef(flagBranch, flagRef string, projectCfg *Config, globalCfg *Globa
 		}
 	}
 
-	// Priority 4: Default
+	// Priority 5: Default
 	return "main"
 }
+
+// ResolveMirrors returns the registry mirrors of the project config, falling back to the global config
+func ResolveMirrors(projectCfg *Config, globalCfg *GlobalConfig) []string {
+	if projectCfg != nil && projectCfg.Registry != nil && len(projectCfg.Registry.Mirrors) > 0 {
+		return projectCfg.Registry.Mirrors
+	}
+	if globalCfg != nil && globalCfg.Registry != nil {
+		return globalCfg.Registry.Mirrors
+	}
+	return nil
+}
+
+// ResolveRepository returns the registry's owner and repo from the project
+// config, falling back to the global config. Both are empty when unset.
+func ResolveRepository(projectCfg *Config, globalCfg *GlobalConfig) (owner, repo string) {
+	repository := ""
+	if projectCfg != nil && projectCfg.Registry != nil {
+		repository = projectCfg.Registry.Repository
+	}
+	if repository == "" && globalCfg != nil && globalCfg.Registry != nil {
+		repository = globalCfg.Registry.Repository
+	}
+
+	owner, repo, _ = strings.Cut(repository, "/")
+	return owner, repo
+}
+
+// ResolveTokenEnv returns the token environment variable named by the global
+// config. A project config is never consulted: it comes with the checkout and
+// could otherwise send any variable to a repository or mirror it chose.
+func ResolveTokenEnv(globalCfg *GlobalConfig) string {
+	if globalCfg != nil && globalCfg.Registry != nil {
+		return globalCfg.Registry.TokenEnv
+	}
+	return ""
+}
+
+// ResolveTarget returns the skills directory from the project config, falling
+// back to the global config. It is empty when unset.
+func ResolveTarget(projectCfg *Config, globalCfg *GlobalConfig) string {
+	if projectCfg != nil && projectCfg.Target != "" {
+		return projectCfg.Target
+	}
+	if globalCfg != nil {
+		return globalCfg.Target
+	}
+	return ""
+}
+
+// ResolveCacheTTL returns the cache TTL from the project config, falling back
+// to the global config. ok is false when neither sets one.
+func ResolveCacheTTL(projectCfg *Config, globalCfg *GlobalConfig) (ttl time.Duration, ok bool, err error) {
+	value := ""
+	if projectCfg != nil && projectCfg.Registry != nil {
+		value = projectCfg.Registry.CacheTTL
+	}
+	if value == "" && globalCfg != nil && globalCfg.Registry != nil {
+		value = globalCfg.Registry.CacheTTL
+	}
+	if value == "" {
+		return 0, false, nil
+	}
+
+	ttl, err = time.ParseDuration(value)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid cache_ttl %q: %w", value, err)
+	}
+	return ttl, true, nil
+}
@@ -0,0 +1,137 @@
+package installer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrBatchFailed marks the skills of an atomic batch that were rolled back or
+// never installed because another skill in the batch failed
+var ErrBatchFailed = errors.New("another skill in the batch failed")
+
+// SetAtomic makes InstallMultiple, InstallStack(s) and InstallAll all or
+// nothing: if any skill fails, every skill installed by the call is removed
+// and any version it replaced is restored, lockfile entries included.
+// Post-install hooks that already ran are not undone.
+func (i *Installer) SetAtomic(atomic bool) {
+	i.atomic = atomic
+}
+
+// transaction remembers the state of each skill directory an atomic batch
+// replaced so the batch can be rolled back
+type transaction struct {
+	mu      sync.Mutex
+	saved   map[string]savedSkill // skill directory -> state before the batch
+	ordered []string
+}
+
+type savedSkill struct {
+	skillsDir string
+	name      string
+	backupDir string     // Where the previous directory was moved, "" if there was none
+	entry     *LockEntry // Previous lockfile entry, nil if there was none
+}
+
+func newTransaction() *transaction {
+	return &transaction{saved: make(map[string]savedSkill)}
+}
+
+// save moves an existing skill directory aside and records its lockfile
+// entry, the first time the batch touches the skill. Later installs of the
+// same skill in the batch keep the original state.
+func (t *transaction) save(skillsDir, skillName string) error {
+	skillDir := filepath.Join(skillsDir, skillName)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.saved[skillDir]; ok {
+		return nil
+	}
+
+	lock, err := readLock(skillsDir)
+	if err != nil {
+		return fmt.Errorf("failed to read lockfile: %w", err)
+	}
+	state := savedSkill{skillsDir: skillsDir, name: skillName}
+	if entry, ok := lock.Skills[skillName]; ok {
+		state.entry = &entry
+	}
+
+	if _, err := os.Stat(skillDir); err == nil {
+		state.backupDir = filepath.Join(skillsDir, "."+skillName+".atomic")
+		if err := os.RemoveAll(state.backupDir); err != nil {
+			return fmt.Errorf("failed to remove stale backup: %w", err)
+		}
+		if err := os.Rename(skillDir, state.backupDir); err != nil {
+			return fmt.Errorf("failed to back up old skill: %w", err)
+		}
+	}
+
+	t.saved[skillDir] = state
+	t.ordered = append(t.ordered, skillDir)
+	return nil
+}
+
+// commit discards the backups once every skill of the batch is installed
+func (t *transaction) commit() {
+	for _, skillDir := range t.ordered {
+		if backupDir := t.saved[skillDir].backupDir; backupDir != "" {
+			_ = os.RemoveAll(backupDir)
+		}
+	}
+}
+
+// rollback puts every skill the batch touched back as it was
+func (i *Installer) rollback(t *transaction) error {
+	var errs []error
+	for idx := len(t.ordered) - 1; idx >= 0; idx-- {
+		skillDir := t.ordered[idx]
+		state := t.saved[skillDir]
+
+		var err error
+		if state.backupDir != "" {
+			err = restoreBackup(state.backupDir, skillDir)
+		} else {
+			err = os.RemoveAll(skillDir)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to roll back %s: %w", state.name, err))
+			continue
+		}
+
+		err = i.updateLock(state.skillsDir, restoreLockEntry(state.name, state.entry))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to roll back lockfile entry of %s: %w", state.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// rollbackResults rolls back an atomic batch and marks the skills it had
+// installed as failed
+func (i *Installer) rollbackResults(t *transaction, results []InstallResult) []InstallResult {
+	rollbackErr := i.rollback(t)
+	for idx := range results {
+		if results[idx].Status != StatusInstalled {
+			continue
+		}
+		err := fmt.Errorf("rolled back: %w", ErrBatchFailed)
+		if rollbackErr != nil {
+			err = fmt.Errorf("%w (%v)", err, rollbackErr)
+		}
+		results[idx] = InstallResult{Name: results[idx].Name, Status: StatusFailed, Err: err, Stacks: results[idx].Stacks}
+	}
+	return results
+}
+
+func hasFailure(results []InstallResult) bool {
+	for _, result := range results {
+		if result.Status == StatusFailed {
+			return true
+		}
+	}
+	return false
+}
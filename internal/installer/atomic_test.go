@@ -0,0 +1,103 @@
+package installer
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestAtomicBatchRollsBack(t *testing.T) {
+	provider := newFakeProvider()
+	provider.addSkill("kept", "v1")
+	inst, skillsDir := newTestInstaller(t, provider)
+	if err := inst.Install("kept"); err != nil {
+		t.Fatal(err)
+	}
+	before, err := readLock(skillsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider.addSkill("kept", "v2")
+	provider.addSkill("fresh", "v1")
+	provider.addSkill("broken", "v1")
+	provider.fail["broken"] = errors.New("download failed")
+
+	inst.SetAtomic(true)
+	inst.SetForce(true)
+	results := inst.InstallMultipleResults([]string{"kept", "fresh", "broken"})
+
+	for _, result := range results {
+		if result.Status != StatusFailed {
+			t.Errorf("%s: status %v, want every skill of a failed batch failed", result.Name, result.Status)
+		}
+		if result.Name != "broken" && !errors.Is(result.Err, ErrBatchFailed) {
+			t.Errorf("%s: error %v, want ErrBatchFailed", result.Name, result.Err)
+		}
+	}
+
+	if got := readSkillFile(t, skillsDir, "kept", "SKILL.md"); !strings.Contains(got, "v1") {
+		t.Errorf("kept: SKILL.md = %q, want v1 restored", got)
+	}
+	if inst.IsInstalled("fresh") {
+		t.Error("fresh is still installed after the rollback")
+	}
+
+	after, err := readLock(skillsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := after.Skills["kept"], before.Skills["kept"]; got.Hash != want.Hash || !got.InstalledAt.Equal(want.InstalledAt) {
+		t.Errorf("kept: lockfile entry %+v, want %+v restored", got, want)
+	}
+	if _, ok := after.Skills["fresh"]; ok {
+		t.Error("fresh: lockfile entry left after the rollback")
+	}
+	assertNoHiddenDirs(t, skillsDir)
+}
+
+func TestAtomicBatchCommits(t *testing.T) {
+	provider := newFakeProvider()
+	provider.addSkill("one", "v1")
+	inst, skillsDir := newTestInstaller(t, provider)
+	if err := inst.Install("one"); err != nil {
+		t.Fatal(err)
+	}
+
+	provider.addSkill("one", "v2")
+	provider.addSkill("two", "v1")
+	inst.SetAtomic(true)
+	inst.SetForce(true)
+	if _, errs := inst.InstallMultiple([]string{"one", "two"}); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+
+	if got := readSkillFile(t, skillsDir, "one", "SKILL.md"); !strings.Contains(got, "v2") {
+		t.Errorf("one: SKILL.md = %q, want v2", got)
+	}
+	lock, err := readLock(skillsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"one", "two"} {
+		if _, ok := lock.Skills[name]; !ok {
+			t.Errorf("%s: no lockfile entry", name)
+		}
+	}
+	assertNoHiddenDirs(t, skillsDir)
+}
+
+// assertNoHiddenDirs fails if a backup or staging directory was left in skillsDir
+func assertNoHiddenDirs(t *testing.T, skillsDir string) {
+	t.Helper()
+	entries, err := os.ReadDir(skillsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && strings.HasPrefix(entry.Name(), ".") {
+			t.Errorf("left behind %s", entry.Name())
+		}
+	}
+}
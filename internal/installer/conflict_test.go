@@ -0,0 +1,127 @@
+package installer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFiles creates files under dir, keyed by slash-separated relative path
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestInstallOverLegacyInstall(t *testing.T) {
+	provider := newFakeProvider()
+	provider.addSkill("legacy", "v2")
+	provider.setFile("legacy", "references/guide.md", "new guide", 0644)
+	inst, skillsDir := newTestInstaller(t, provider)
+
+	// What installs wrote before the lockfile and manifests existed
+	writeFiles(t, filepath.Join(skillsDir, "legacy"), map[string]string{
+		"SKILL.md":            "---\nname: legacy\ndescription: test skill\n---\nv1\n",
+		"references/guide.md": "old guide",
+		"references/old.md":   "removed upstream since",
+	})
+
+	if err := inst.Install("legacy"); err != nil {
+		t.Fatalf("Install over a legacy install: %v", err)
+	}
+	if got := readSkillFile(t, skillsDir, "legacy", "SKILL.md"); !strings.Contains(got, "v2") {
+		t.Errorf("SKILL.md = %q, want v2", got)
+	}
+	lock, err := readLock(skillsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := lock.Skills["legacy"]; !ok {
+		t.Error("reinstalled legacy skill has no lockfile entry")
+	}
+
+	// From here on the lockfile decides
+	writeFiles(t, filepath.Join(skillsDir, "legacy"), map[string]string{"notes.md": "mine"})
+	var conflict *ConflictError
+	if err := inst.Install("legacy"); !errors.As(err, &conflict) {
+		t.Fatalf("Install error = %v, want a ConflictError for notes.md", err)
+	}
+}
+
+func TestInstallConflicts(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     map[string]string
+		manifest  []string // Files listed in a manifest, none when nil
+		conflicts []string
+	}{
+		{
+			name:      "files without SKILL.md",
+			files:     map[string]string{"notes.md": "mine"},
+			conflicts: []string{"notes.md"},
+		},
+		{
+			name: "SKILL.md of another skill",
+			files: map[string]string{
+				"SKILL.md": "---\nname: something-else\ndescription: mine\n---\n",
+			},
+			conflicts: []string{"SKILL.md"},
+		},
+		{
+			name: "manifest without lock entry",
+			files: map[string]string{
+				"SKILL.md": "---\nname: target\ndescription: test skill\n---\nv1\n",
+				"notes.md": "mine",
+			},
+			manifest:  []string{"SKILL.md"},
+			conflicts: []string{"notes.md"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newFakeProvider()
+			provider.addSkill("target", "v2")
+			inst, skillsDir := newTestInstaller(t, provider)
+			skillDir := filepath.Join(skillsDir, "target")
+			writeFiles(t, skillDir, tt.files)
+			if tt.manifest != nil {
+				m := &Manifest{Version: manifestVersion, Skill: "target", Files: map[string]string{}}
+				for _, p := range tt.manifest {
+					m.Files[p] = "0"
+				}
+				if err := writeManifest(skillDir, m); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := inst.Install("target")
+			var conflict *ConflictError
+			if !errors.As(err, &conflict) {
+				t.Fatalf("Install error = %v, want a ConflictError", err)
+			}
+			var got []string
+			for _, path := range conflict.Paths {
+				rel, _ := filepath.Rel(skillDir, path)
+				got = append(got, filepath.ToSlash(rel))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.conflicts, ",") {
+				t.Errorf("conflicts = %v, want %v", got, tt.conflicts)
+			}
+
+			inst.SetForce(true)
+			if err := inst.Install("target"); err != nil {
+				t.Errorf("forced Install: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package installer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ResolveDependencies expands skillNames to include their transitive
+// dependencies. Dependencies come before the skills that need them, each
+// skill appears once, and cycles are reported as errors.
+func (i *Installer) ResolveDependencies(skillNames []string) ([]string, error) {
+	const (
+		visiting = 1
+		done     = 2
+	)
+
+	state := make(map[string]int)
+	var order []string
+	var path []string
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		skill, err := i.findSkill(name)
+		if err != nil {
+			if len(path) > 0 {
+				return fmt.Errorf("dependency of %s: %w", path[len(path)-1], err)
+			}
+			return err
+		}
+
+		switch state[skill.Name] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("dependency cycle: %s -> %s", strings.Join(path, " -> "), skill.Name)
+		}
+
+		state[skill.Name] = visiting
+		path = append(path, skill.Name)
+		for _, dep := range skill.Dependencies {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[skill.Name] = done
+		order = append(order, skill.Name)
+		return nil
+	}
+
+	for _, name := range skillNames {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// InstallWithDependencies installs a skill together with everything it depends
+// on and returns the names of all installed skills
+func (i *Installer) InstallWithDependencies(skillName string) ([]string, error) {
+	installed, errs := splitResults(i.installConcurrently(context.Background(), []string{skillName}), false)
+	if len(errs) > 0 {
+		return installed, errs[0]
+	}
+	return installed, nil
+}
+
+// expandDependencies adds the dependencies of names, listing each skill once.
+// Names whose dependencies cannot be resolved are reported as failed results,
+// and names repeating a skill already listed are reported as skipped.
+func (i *Installer) expandDependencies(names []string) (expanded []string, results []InstallResult) {
+	seen := make(map[string]bool)
+
+	for _, name := range names {
+		resolved, err := i.ResolveDependencies([]string{name})
+		if err != nil {
+			results = append(results, InstallResult{Name: name, Status: StatusFailed, Err: err})
+			continue
+		}
+
+		for idx, skillName := range resolved {
+			last := idx == len(resolved)-1
+			if seen[skillName] {
+				if last {
+					results = append(results, InstallResult{Name: name, Status: StatusSkipped})
+				}
+				continue
+			}
+			seen[skillName] = true
+			// Keep the requested spelling (e.g. "stack/name") for the skill itself
+			if last {
+				skillName = name
+			}
+			expanded = append(expanded, skillName)
+		}
+	}
+	return
+}
@@ -0,0 +1,89 @@
+package installer
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"sort"
+)
+
+// DiffStatus classifies how a file differs between the installed and registry versions
+type DiffStatus string
+
+const (
+	DiffAdded    DiffStatus = "added"    // Present in the registry but not installed
+	DiffRemoved  DiffStatus = "removed"  // Installed but no longer in the registry
+	DiffModified DiffStatus = "modified" // Content differs
+)
+
+// FileDiff describes a single changed file, relative to the skill directory
+type FileDiff struct {
+	Path   string
+	Status DiffStatus
+}
+
+// Diff compares an installed skill against the registry version and returns
+// the files that an update would change, sorted by path
+func (i *Installer) Diff(skillName string) ([]FileDiff, error) {
+	scope, ok := i.InstalledScope(skillName)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotInstalled, skillName)
+	}
+
+	skill, err := i.findSkill(skillName)
+	if err != nil {
+		return nil, err
+	}
+
+	skillFiles, err := i.provider.GetSkillFiles(skill)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch skill files: %w", err)
+	}
+
+	local, err := readDirFiles(filepath.Join(i.dirFor(scope), skillName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read installed skill: %w", err)
+	}
+
+	only, err := i.onlyFilesFor(i.dirFor(scope), skillName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Compare against the files as they were written
+	crlf, err := i.crlfFor(i.dirFor(scope), skillName)
+	if err != nil {
+		return nil, err
+	}
+	remote := fileContents(skillFiles)
+	if crlf {
+		remote = crlfContents(skillFiles)
+	}
+	// Files left out by --only are not missing
+	remote = filterContents(remote, only)
+
+	return diffFiles(local, remote), nil
+}
+
+// diffFiles compares two relative path -> content maps
+func diffFiles(local, remote map[string][]byte) []FileDiff {
+	var diffs []FileDiff
+	for path, content := range remote {
+		path = filepath.ToSlash(path)
+		installed, ok := local[path]
+		switch {
+		case !ok:
+			diffs = append(diffs, FileDiff{Path: path, Status: DiffAdded})
+		case !bytes.Equal(installed, content):
+			diffs = append(diffs, FileDiff{Path: path, Status: DiffModified})
+		}
+	}
+	for path := range local {
+		if _, ok := remote[path]; !ok {
+			diffs = append(diffs, FileDiff{Path: path, Status: DiffRemoved})
+		}
+	}
+
+	sort.Slice(diffs, func(a, b int) bool { return diffs[a].Path < diffs[b].Path })
+	return diffs
+}
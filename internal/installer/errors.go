@@ -0,0 +1,32 @@
+package installer
+
+import (
+	"errors"
+	"io/fs"
+
+	"github.com/cuongtl1992/vibe-skills/internal/registry"
+)
+
+// Error classes returned by the installer, for use with errors.Is
+var (
+	// ErrSkillNotFound means the provider has no skill with the requested name
+	ErrSkillNotFound = registry.ErrSkillNotFound
+
+	// ErrNotInstalled means the skill is not installed in any scope
+	ErrNotInstalled = errors.New("skill not installed")
+
+	// ErrNetwork means the provider could not be reached
+	ErrNetwork = registry.ErrNetwork
+
+	// ErrConflict means installing would overwrite files vibe-skills did not
+	// write; errors.As with *ConflictError gives the paths
+	ErrConflict = errors.New("conflicting files")
+
+	// ErrPermission means a file or directory could not be read or written
+	ErrPermission = fs.ErrPermission
+)
+
+// Is makes a ConflictError match ErrConflict
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
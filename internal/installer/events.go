@@ -0,0 +1,38 @@
+package installer
+
+// Phase identifies a step of installing a skill
+type Phase string
+
+const (
+	PhaseResolving Phase = "resolving"
+	PhaseFetching  Phase = "fetching"
+	PhaseWriting   Phase = "writing"
+	PhaseHook      Phase = "hook"
+	PhaseDone      Phase = "done"
+	PhaseError     Phase = "error"
+)
+
+// InstallEvent reports progress of a single skill install
+type InstallEvent struct {
+	Skill  string
+	Phase  Phase
+	Path   string // File being written, or hook script run, for PhaseWriting and PhaseHook
+	Output string // Combined hook output, set for PhaseHook
+	Err    error  // Failure cause, set for PhaseError
+}
+
+// OnProgress registers fn to receive install events. Calls are serialized, so
+// fn need not be safe for concurrent use even when skills install in parallel.
+func (i *Installer) OnProgress(fn func(InstallEvent)) {
+	i.eventMu.Lock()
+	defer i.eventMu.Unlock()
+	i.onProgress = fn
+}
+
+func (i *Installer) emit(event InstallEvent) {
+	i.eventMu.Lock()
+	defer i.eventMu.Unlock()
+	if i.onProgress != nil {
+		i.onProgress(event)
+	}
+}
@@ -0,0 +1,83 @@
+package installer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// gitTimeout bounds each git command run to inspect a skill directory
+const gitTimeout = 10 * time.Second
+
+// TrackedFiles returns the files of an installed skill that are tracked by a
+// Git repository around the skills directory, relative to the skill
+// directory. It returns nothing when the skill is not in a Git work tree or
+// git is not installed.
+func (i *Installer) TrackedFiles(skillName string) ([]string, error) {
+	return i.gitFiles(skillName, "ls-files", "-z", "--", ".")
+}
+
+// ModifiedTrackedFiles returns the Git-tracked files of an installed skill
+// that differ from the Git index, relative to the skill directory, such as
+// files an install or update just rewrote. It returns nothing when the skill
+// is not in a Git work tree or git is not installed.
+func (i *Installer) ModifiedTrackedFiles(skillName string) ([]string, error) {
+	return i.gitFiles(skillName, "diff", "--name-only", "-z", "--relative", "--", ".")
+}
+
+// gitFiles runs git with args in the skill's directory and returns the
+// NUL-separated paths it prints
+func (i *Installer) gitFiles(skillName string, args ...string) ([]string, error) {
+	skillsDir, err := i.findSkillsDir(skillName)
+	if err != nil || skillsDir == "" {
+		return nil, err
+	}
+	skillDir := filepath.Join(skillsDir, skillName)
+	if !inGitWorkTree(skillDir) {
+		return nil, nil
+	}
+	git, err := exec.LookPath("git")
+	if err != nil {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), gitTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, git, append([]string{"-C", skillDir}, args...)...)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run git %s: %w", args[0], err)
+	}
+
+	var files []string
+	for _, path := range strings.Split(string(output), "\x00") {
+		if path != "" {
+			files = append(files, filepath.FromSlash(path))
+		}
+	}
+	return files, nil
+}
+
+// inGitWorkTree reports whether dir or one of its parents holds a .git
+// directory, or a .git file as in worktrees and submodules
+func inGitWorkTree(dir string) bool {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return false
+		}
+		dir = parent
+	}
+}
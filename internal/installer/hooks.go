@@ -0,0 +1,84 @@
+package installer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const (
+	HookPreInstall  = "pre-install"
+	HookPostInstall = "post-install"
+
+	// HookTimeout bounds how long a single hook script may run
+	HookTimeout = 60 * time.Second
+)
+
+// SetRunHooks enables running hooks/pre-install and hooks/post-install
+// scripts shipped with a skill. Hooks execute arbitrary code, so they are
+// disabled unless explicitly enabled. A failing hook fails the install and
+// leaves the skill's files and lockfile entry as they were before it.
+func (i *Installer) SetRunHooks(run bool) {
+	i.runHooks = run
+}
+
+// hookScript returns the path of the hook script in dir for this platform, if the skill ships one
+func hookScript(dir, hook string) (string, bool) {
+	ext := ".sh"
+	if runtime.GOOS == "windows" {
+		ext = ".ps1"
+	}
+	script := filepath.Join(dir, "hooks", hook+ext)
+	info, err := os.Stat(script)
+	if err != nil || !info.Mode().IsRegular() {
+		return "", false
+	}
+	return script, true
+}
+
+func hookCommand(ctx context.Context, script string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "powershell", "-NoProfile", "-NonInteractive", "-ExecutionPolicy", "Bypass", "-File", script)
+	}
+	return exec.CommandContext(ctx, "sh", script)
+}
+
+// runHook runs a skill's hook with workDir as working directory. skillDir is
+// the final install location, which differs from workDir for pre-install hooks
+// that run against the staged files.
+func (i *Installer) runHook(skillName, hook, workDir, skillDir string) error {
+	if !i.runHooks {
+		return nil
+	}
+	script, ok := hookScript(workDir, hook)
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), HookTimeout)
+	defer cancel()
+
+	cmd := hookCommand(ctx, script)
+	cmd.Dir = workDir
+	cmd.Env = append(os.Environ(), "VIBE_SKILL_NAME="+skillName, "VIBE_SKILL_DIR="+skillDir)
+
+	output, err := cmd.CombinedOutput()
+	i.emit(InstallEvent{Skill: skillName, Phase: PhaseHook, Path: script, Output: string(output)})
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%s hook timed out after %s", hook, HookTimeout)
+	}
+	if err != nil {
+		if out := strings.TrimSpace(string(output)); out != "" {
+			return fmt.Errorf("%s hook failed: %w: %s", hook, err, out)
+		}
+		return fmt.Errorf("%s hook failed: %w", hook, err)
+	}
+	return nil
+}
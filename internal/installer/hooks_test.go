@@ -0,0 +1,128 @@
+package installer
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+)
+
+const failingHook = "echo hook failed >&2\nexit 1\n"
+
+func skipWithoutSh(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("hook tests use sh scripts")
+	}
+}
+
+func TestFailingPostInstallHookOnFreshInstall(t *testing.T) {
+	skipWithoutSh(t)
+	provider := newFakeProvider()
+	provider.addSkill("hooked", "v1")
+	provider.setFile("hooked", "hooks/post-install.sh", failingHook, 0755)
+
+	inst, skillsDir := newTestInstaller(t, provider)
+	inst.SetRunHooks(true)
+
+	err := inst.Install("hooked")
+	if err == nil || !strings.Contains(err.Error(), "hook failed") {
+		t.Fatalf("Install error = %v, want the hook's failure", err)
+	}
+	if inst.IsInstalled("hooked") {
+		t.Error("skill is installed after its post-install hook failed")
+	}
+	lock, err := readLock(skillsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := lock.Skills["hooked"]; ok {
+		t.Error("lockfile records a skill whose post-install hook failed")
+	}
+}
+
+func TestFailingPostInstallHookRestoresPreviousVersion(t *testing.T) {
+	skipWithoutSh(t)
+
+	for _, tt := range []struct {
+		name    string
+		install func(inst *Installer) error
+	}{
+		{"update", func(inst *Installer) error { return inst.Update("hooked", false) }},
+		{"forced install", func(inst *Installer) error {
+			inst.SetForce(true)
+			return inst.Install("hooked")
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newFakeProvider()
+			provider.addSkill("hooked", "v1")
+			inst, skillsDir := newTestInstaller(t, provider)
+			inst.SetRunHooks(true)
+			if err := inst.Install("hooked"); err != nil {
+				t.Fatal(err)
+			}
+			before, err := readLock(skillsDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			provider.addSkill("hooked", "v2")
+			provider.setFile("hooked", "hooks/post-install.sh", failingHook, 0755)
+			if err := tt.install(inst); err == nil {
+				t.Fatal("install with a failing post-install hook succeeded")
+			}
+
+			if got := readSkillFile(t, skillsDir, "hooked", "SKILL.md"); !strings.Contains(got, "v1") {
+				t.Errorf("SKILL.md after the failed hook = %q, want v1 restored", got)
+			}
+			if got := readSkillFile(t, skillsDir, "hooked", "hooks/post-install.sh"); got != "" {
+				t.Error("files of the failed version were left behind")
+			}
+			after, err := readLock(skillsDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if after.Skills["hooked"].Hash != before.Skills["hooked"].Hash {
+				t.Error("lockfile entry was not rolled back with the files")
+			}
+			if modified, err := inst.IsModified("hooked"); err != nil || modified {
+				t.Errorf("IsModified = %v, %v after rollback, want false", modified, err)
+			}
+		})
+	}
+}
+
+func TestFailingPreInstallHookChangesNothing(t *testing.T) {
+	skipWithoutSh(t)
+	provider := newFakeProvider()
+	provider.addSkill("hooked", "v1")
+	inst, skillsDir := newTestInstaller(t, provider)
+	inst.SetRunHooks(true)
+	if err := inst.Install("hooked"); err != nil {
+		t.Fatal(err)
+	}
+
+	provider.addSkill("hooked", "v2")
+	provider.setFile("hooked", "hooks/pre-install.sh", failingHook, 0755)
+	if err := inst.Update("hooked", false); err == nil {
+		t.Fatal("update with a failing pre-install hook succeeded")
+	}
+	if got := readSkillFile(t, skillsDir, "hooked", "SKILL.md"); !strings.Contains(got, "v1") {
+		t.Errorf("SKILL.md = %q, want v1", got)
+	}
+	if modified, err := inst.IsModified("hooked"); err != nil || modified {
+		t.Errorf("IsModified = %v, %v, want false", modified, err)
+	}
+}
+
+func TestHooksDisabledByDefault(t *testing.T) {
+	skipWithoutSh(t)
+	provider := newFakeProvider()
+	provider.addSkill("hooked", "v1")
+	provider.setFile("hooked", "hooks/post-install.sh", failingHook, 0755)
+	inst, _ := newTestInstaller(t, provider)
+
+	if err := inst.Install("hooked"); err != nil {
+		t.Fatalf("Install ran a hook without SetRunHooks: %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package installer
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// InstalledSkill describes an installed skill and its footprint on disk
+type InstalledSkill struct {
+	Name        string
+	Scope       Scope
+	Dir         string
+	Stack       string // From the lockfile, "" for skills it does not track
+	Ref         string
+	Tracked     bool      // Whether the lockfile has an entry for the skill
+	Files       int       // Regular files in the skill directory, the manifest excluded
+	Size        int64     // Total size of those files in bytes
+	InstalledAt time.Time // From the lockfile, else the manifest or directory mtime
+}
+
+// ListInstalledDetailed is ListInstalled with the lockfile entry, file count,
+// size and install time of each skill
+func (i *Installer) ListInstalledDetailed() ([]InstalledSkill, error) {
+	var skills []InstalledSkill
+	seen := make(map[string]bool)
+
+	for _, scope := range i.searchScopes() {
+		skillsDir := i.dirFor(scope)
+		names, err := listInstalledIn(skillsDir)
+		if err != nil {
+			return nil, err
+		}
+		if len(names) == 0 {
+			continue
+		}
+
+		lock, err := readLock(skillsDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read lockfile: %w", err)
+		}
+
+		for _, name := range names {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			skill, err := describeSkillDir(filepath.Join(skillsDir, name))
+			if err != nil {
+				return nil, fmt.Errorf("failed to inspect %s: %w", name, err)
+			}
+			skill.Name = name
+			skill.Scope = scope
+			if entry, ok := lock.Skills[name]; ok {
+				skill.Stack = entry.Stack
+				skill.Ref = entry.Ref
+				skill.Tracked = true
+				if !entry.InstalledAt.IsZero() {
+					skill.InstalledAt = entry.InstalledAt
+				}
+			}
+			skills = append(skills, skill)
+		}
+	}
+
+	return skills, nil
+}
+
+// describeSkillDir counts the files of a skill directory and dates it by its
+// manifest, or the directory itself for skills installed without one
+func describeSkillDir(skillDir string) (InstalledSkill, error) {
+	skill := InstalledSkill{Dir: skillDir}
+
+	err := filepath.WalkDir(skillDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if path == filepath.Join(skillDir, ManifestFileName) {
+			skill.InstalledAt = info.ModTime()
+			return nil
+		}
+		skill.Files++
+		skill.Size += info.Size()
+		return nil
+	})
+	if err != nil {
+		return skill, err
+	}
+
+	if skill.InstalledAt.IsZero() {
+		info, err := os.Stat(skillDir)
+		if err != nil {
+			return skill, err
+		}
+		skill.InstalledAt = info.ModTime()
+	}
+	return skill, nil
+}
@@ -1,136 +1,776 @@
 package installer
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/cuongtl1992/vibe-skills/internal/logging"
 	"github.com/cuongtl1992/vibe-skills/internal/registry"
 )
 
-const TargetDir = ".claude/skills"
+const (
+	TargetDir = ".claude/skills"
+
+	// DefaultMaxParallel is how many skills are installed concurrently by default
+	DefaultMaxParallel = 4
+)
 
 // SkillProvider defines the interface for skill sources
 type SkillProvider interface {
 	Find(name string) (*registry.Skill, error)
 	List() ([]registry.Skill, error)
 	ListByStack(stack string) ([]registry.Skill, error)
+	ListByTag(tag string) ([]registry.Skill, error)
 	GetContent(skill *registry.Skill) ([]byte, error)
 	GetFiles(skill *registry.Skill) (map[string][]byte, error)
+	GetSkillFiles(skill *registry.Skill) (map[string]registry.SkillFile, error)
+	GetRef() string
+}
+
+// Scope selects where skills are installed
+type Scope int
+
+const (
+	// ScopeProject installs into the project's target directory
+	ScopeProject Scope = iota
+	// ScopeGlobal installs into ~/.claude/skills, shared by all projects
+	ScopeGlobal
+)
+
+func (s Scope) String() string {
+	if s == ScopeGlobal {
+		return "global"
+	}
+	return "project"
 }
 
 type Installer struct {
-	provider SkillProvider
-	baseDir  string
+	provider    SkillProvider
+	baseDir     string
+	targetDir   string
+	globalDir   string
+	scope       Scope
+	maxParallel int
+	force       bool
+	runHooks    bool
+	onlyFiles   []string
+	crlf        *bool
+	atomic      bool
+	lockMu      sync.Mutex
+	skillLocks  sync.Map // skill directory -> *sync.Mutex
+	eventMu     sync.Mutex
+	onProgress  func(InstallEvent)
 }
 
 func New(provider SkillProvider, baseDir string) *Installer {
+	return NewWithTarget(provider, baseDir, TargetDir)
+}
+
+// NewWithTarget creates an installer that places skills in targetDir instead of
+// .claude/skills. A relative targetDir is resolved against baseDir.
+func NewWithTarget(provider SkillProvider, baseDir, targetDir string) *Installer {
+	if targetDir == "" {
+		targetDir = TargetDir
+	}
+
+	// Global installs are unavailable if the home directory cannot be determined
+	var globalDir string
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		globalDir = filepath.Join(homeDir, TargetDir)
+	}
+
 	return &Installer{
-		provider: provider,
-		baseDir:  baseDir,
+		provider:    provider,
+		baseDir:     baseDir,
+		targetDir:   targetDir,
+		globalDir:   globalDir,
+		maxParallel: DefaultMaxParallel,
+	}
+}
+
+// SetScope selects whether Install writes to the project or the global directory.
+// With ScopeGlobal, lookups and removals only consider global skills.
+func (i *Installer) SetScope(scope Scope) {
+	i.scope = scope
+}
+
+// SkillsDir returns the directory skills are installed into for the current scope
+func (i *Installer) SkillsDir() string {
+	return i.dirFor(i.scope)
+}
+
+// SkillsDirFor returns the directory skills of the given scope are installed into
+func (i *Installer) SkillsDirFor(scope Scope) string {
+	return i.dirFor(scope)
+}
+
+func (i *Installer) dirFor(scope Scope) string {
+	if scope == ScopeGlobal {
+		return i.globalDir
+	}
+	if filepath.IsAbs(i.targetDir) {
+		return i.targetDir
+	}
+	return filepath.Join(i.baseDir, i.targetDir)
+}
+
+// searchScopes returns the scopes consulted by lookups, most specific first
+func (i *Installer) searchScopes() []Scope {
+	if i.scope == ScopeGlobal {
+		return []Scope{ScopeGlobal}
+	}
+	if i.globalDir == "" || i.globalDir == i.dirFor(ScopeProject) {
+		return []Scope{ScopeProject}
+	}
+	return []Scope{ScopeProject, ScopeGlobal}
+}
+
+// InstalledScope returns the scope a skill is installed in, preferring the project
+func (i *Installer) InstalledScope(skillName string) (Scope, bool) {
+	for _, scope := range i.searchScopes() {
+		if isInstalledIn(i.dirFor(scope), skillName) {
+			return scope, true
+		}
+	}
+	return ScopeProject, false
+}
+
+// SetForce controls whether Install overwrites files it did not create
+func (i *Installer) SetForce(force bool) {
+	i.force = force
+}
+
+// ConflictError reports existing files in a skill directory that were not
+// written by a previous install and would be lost by installing over them
+type ConflictError struct {
+	Skill string
+	Paths []string // Absolute paths of the conflicting files
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%d file(s) in %s were not installed by vibe-skills and would be overwritten (use --force): %s",
+		len(e.Paths), e.Skill, strings.Join(e.Paths, ", "))
+}
+
+// SetMaxParallel sets how many skills InstallMultiple, InstallStack and
+// InstallAll install, and UpdateAll updates, at once. Values below 1 work
+// sequentially.
+func (i *Installer) SetMaxParallel(n int) {
+	if n < 1 {
+		n = 1
 	}
+	i.maxParallel = n
 }
 
 func (i *Installer) Install(skillName string) error {
-	skill, err := i.provider.Find(skillName)
+	_, _, err := i.installInto(i.SkillsDir(), skillName, i.force)
+	return err
+}
+
+// InstallWithResult installs a skill like Install and reports how many files
+// and bytes it wrote
+func (i *Installer) InstallWithResult(skillName string) InstallResult {
+	files, size, err := i.installInto(i.SkillsDir(), skillName, i.force)
+	if err != nil {
+		return InstallResult{Name: skillName, Status: StatusFailed, Err: err}
+	}
+	return InstallResult{Name: skillName, Status: StatusInstalled, FilesWritten: files, BytesWritten: size}
+}
+
+// Reinstall installs a skill fresh, replacing its directory even if it holds
+// a partial install that IsInstalled does not recognise or files that would
+// otherwise be reported as conflicts
+func (i *Installer) Reinstall(skillName string) error {
+	skill, err := i.findSkill(skillName)
+	if err != nil {
+		return err
+	}
+
+	skillsDir, err := i.findSkillsDir(skill.Name)
+	if err != nil {
+		return err
+	}
+	if skillsDir == "" {
+		skillsDir = i.SkillsDir()
+	}
+
+	_, _, err = i.installInto(skillsDir, skill.Name, true)
+	return err
+}
+
+// installInto installs a skill under skillsDir and returns how many files and
+// bytes it wrote. With force, files not written by a previous install are
+// overwritten instead of reported as conflicts.
+func (i *Installer) installInto(skillsDir, skillName string, force bool) (written int, size int64, err error) {
+	return i.installFiles(skillsDir, skillName, force, nil, nil)
+}
+
+// installFiles is installInto with the skill's files already fetched, or
+// fetched from the provider when files is nil. With a transaction, the
+// directory being replaced is kept for rollback instead of deleted.
+func (i *Installer) installFiles(skillsDir, skillName string, force bool, files map[string]registry.SkillFile, txn *transaction) (written int, size int64, err error) {
+	defer func() { i.emitResult(skillName, err) }()
+
+	if skillsDir == "" {
+		return 0, 0, fmt.Errorf("cannot determine install directory")
+	}
+
+	i.emit(InstallEvent{Skill: skillName, Phase: PhaseResolving})
+	skill, err := i.findSkill(skillName)
 	if err != nil {
-		return fmt.Errorf("skill not found: %s", skillName)
+		return 0, 0, err
 	}
 
-	// Always install to folder: .claude/skills/{skill-name}/
-	skillDir := filepath.Join(i.baseDir, TargetDir, skill.Name)
+	// Serialize concurrent installs of the same skill (e.g. "name" and "stack/name")
+	unlock := i.lockSkill(skillsDir, skill.Name)
+	defer unlock()
+
+	return i.installLocked(skillsDir, skillName, skill, force, files, txn)
+}
+
+// lockSkill serializes work on one skill directory within the installer, and
+// returns a function releasing it
+func (i *Installer) lockSkill(skillsDir, skillName string) func() {
+	mu, _ := i.skillLocks.LoadOrStore(filepath.Join(skillsDir, skillName), &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
+// emitResult reports the end of an install as a done or error event
+func (i *Installer) emitResult(skillName string, err error) {
+	if err != nil {
+		i.emit(InstallEvent{Skill: skillName, Phase: PhaseError, Err: err})
+	} else {
+		i.emit(InstallEvent{Skill: skillName, Phase: PhaseDone})
+	}
+}
+
+// installLocked is installFiles for a skill already looked up, with its
+// directory locked by the caller
+func (i *Installer) installLocked(skillsDir, skillName string, skill *registry.Skill, force bool, files map[string]registry.SkillFile, txn *transaction) (written int, size int64, err error) {
+	if err := ValidateSkillName(skill.Name); err != nil {
+		return 0, 0, err
+	}
+
+	// Always install to folder: <target>/{skill-name}/
+	skillDir := filepath.Join(skillsDir, skill.Name)
 
 	// Fetch all files (at minimum SKILL.md)
-	files, err := i.provider.GetFiles(skill)
+	if files == nil {
+		i.emit(InstallEvent{Skill: skillName, Phase: PhaseFetching})
+		files, err = i.provider.GetSkillFiles(skill)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to fetch skill files: %w", err)
+		}
+	}
+	only, err := i.onlyFilesFor(skillsDir, skill.Name)
 	if err != nil {
-		return fmt.Errorf("failed to fetch skill files: %w", err)
+		return 0, 0, err
+	}
+	crlf, err := i.crlfFor(skillsDir, skill.Name)
+	if err != nil {
+		return 0, 0, err
+	}
+	for relPath, file := range files {
+		if !selectsFile(filepath.ToSlash(relPath), only) {
+			delete(files, relPath)
+			continue
+		}
+		if crlf && convertsToCRLF(file.Mode, file.Content) {
+			file.Content = toCRLF(file.Content)
+			files[relPath] = file
+		}
 	}
+	contents := fileContents(files)
 
-	for relPath, content := range files {
-		fullPath := filepath.Join(skillDir, relPath)
+	if err := ValidateSkill(skill, contents); err != nil {
+		return 0, 0, fmt.Errorf("invalid skill: %w", err)
+	}
+
+	if !force {
+		if err := i.checkConflicts(skillsDir, skill.Name); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	// Write into a temp sibling first so a failure never leaves a half-written skill
+	tmpDir := filepath.Join(skillsDir, "."+skill.Name+".tmp")
+	if err := os.RemoveAll(tmpDir); err != nil {
+		return 0, 0, fmt.Errorf("failed to clean temp directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	for relPath, file := range files {
+		fullPath, err := containedPath(tmpDir, relPath)
+		if err != nil {
+			return 0, 0, err
+		}
+		i.emit(InstallEvent{Skill: skillName, Phase: PhaseWriting, Path: filepath.Join(skillDir, relPath)})
+		logging.Verbosef("write %s", filepath.Join(skillDir, relPath))
 
 		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
-			return fmt.Errorf("failed to create directory for %s: %w", relPath, err)
+			return 0, 0, fmt.Errorf("failed to create directory for %s: %w", relPath, err)
+		}
+
+		mode := file.Mode
+		if mode == 0 {
+			mode = 0644
+		}
+		if err := os.WriteFile(fullPath, file.Content, mode); err != nil {
+			return 0, 0, fmt.Errorf("failed to write %s: %w", relPath, err)
+		}
+		size += int64(len(file.Content))
+	}
+
+	if err := writeManifest(tmpDir, newManifest(skill.Name, contents)); err != nil {
+		return 0, 0, fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	if err := i.runHook(skillName, HookPreInstall, tmpDir, skillDir); err != nil {
+		return 0, 0, err
+	}
+
+	if txn != nil {
+		if err := txn.save(skillsDir, skill.Name); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	// Keep the replaced version until the post-install hook succeeds, so a
+	// failing hook leaves both the files and the lockfile entry as they were
+	oldDir := filepath.Join(skillsDir, "."+skill.Name+".old")
+	if err := os.RemoveAll(oldDir); err != nil {
+		return 0, 0, fmt.Errorf("failed to clean old directory: %w", err)
+	}
+	hadOld := false
+	if _, err := os.Stat(skillDir); err == nil {
+		if err := os.Rename(skillDir, oldDir); err != nil {
+			return 0, 0, fmt.Errorf("failed to move existing skill aside: %w", err)
+		}
+		hadOld = true
+	}
+	undoFiles := func() error {
+		if err := os.RemoveAll(skillDir); err != nil {
+			return err
+		}
+		if hadOld {
+			return os.Rename(oldDir, skillDir)
+		}
+		return nil
+	}
+	if err := os.Rename(tmpDir, skillDir); err != nil {
+		_ = undoFiles()
+		return 0, 0, fmt.Errorf("failed to move skill into place: %w", err)
+	}
+
+	var previous *LockEntry
+	err = i.updateLock(skillsDir, func(lock *Lock) {
+		if entry, ok := lock.Skills[skill.Name]; ok {
+			previous = &entry
+		}
+		lock.Skills[skill.Name] = LockEntry{
+			Stack:       skill.Stack,
+			Ref:         i.provider.GetRef(),
+			InstalledAt: time.Now().UTC(),
+			Hash:        hashFiles(contents),
+			Files:       sortedPaths(contents),
+			Only:        only,
+			CRLF:        crlf,
+		}
+	})
+	if err != nil {
+		_ = undoFiles()
+		return 0, 0, err
+	}
+
+	if err := i.runHook(skillName, HookPostInstall, skillDir, skillDir); err != nil {
+		undoErr := undoFiles()
+		if lockErr := i.updateLock(skillsDir, restoreLockEntry(skill.Name, previous)); undoErr == nil {
+			undoErr = lockErr
+		}
+		if undoErr != nil {
+			return 0, 0, fmt.Errorf("%w (restoring previous version failed: %v)", err, undoErr)
+		}
+		return 0, 0, err
+	}
+
+	_ = os.RemoveAll(oldDir)
+	return len(files), size, nil
+}
+
+// findSkill looks up a skill with the provider. Lookup failures other than an
+// unknown name, such as network errors, are returned as they are.
+func (i *Installer) findSkill(skillName string) (*registry.Skill, error) {
+	skill, err := i.provider.Find(skillName)
+	if errors.Is(err, ErrSkillNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrSkillNotFound, skillName)
+	}
+	return skill, err
+}
+
+// fileContents strips file modes, leaving relative path -> content
+func fileContents(files map[string]registry.SkillFile) map[string][]byte {
+	contents := make(map[string][]byte, len(files))
+	for path, file := range files {
+		contents[path] = file.Content
+	}
+	return contents
+}
+
+// checkConflicts returns a ConflictError if skillDir holds files that the
+// lockfile does not attribute to a previous install of the skill. Skills
+// without a lock entry go by their manifest, and ones installed before
+// manifests and the lockfile existed are recognised by their SKILL.md.
+func (i *Installer) checkConflicts(skillsDir, skillName string) error {
+	skillDir := filepath.Join(skillsDir, skillName)
+	existing, err := readDirFiles(skillDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
 		}
+		return fmt.Errorf("failed to inspect existing skill: %w", err)
+	}
+	if len(existing) == 0 {
+		return nil
+	}
 
-		if err := os.WriteFile(fullPath, content, 0644); err != nil {
-			return fmt.Errorf("failed to write %s: %w", relPath, err)
+	lock, err := readLock(skillsDir)
+	if err != nil {
+		return fmt.Errorf("failed to read lockfile: %w", err)
+	}
+	tracked := make(map[string]bool)
+	entry, locked := lock.Skills[skillName]
+	switch {
+	case locked:
+		for _, p := range entry.Files {
+			tracked[p] = true
+		}
+	default:
+		manifest, err := readManifest(skillDir)
+		switch {
+		case err == nil:
+			for p := range manifest.Files {
+				tracked[p] = true
+			}
+		case errors.Is(err, ErrNoManifest) && declaresSkill(skillDir, skillName):
+			// Written by a version of vibe-skills that kept no records
+			return nil
 		}
 	}
 
+	var conflicts []string
+	for _, rel := range sortedPaths(existing) {
+		if !tracked[rel] {
+			conflicts = append(conflicts, filepath.Join(skillDir, filepath.FromSlash(rel)))
+		}
+	}
+	if len(conflicts) > 0 {
+		return &ConflictError{Skill: skillName, Paths: conflicts}
+	}
 	return nil
 }
 
-func (i *Installer) InstallMultiple(skillNames []string) (installed []string, errors []error) {
-	for _, name := range skillNames {
-		if err := i.Install(name); err != nil {
-			errors = append(errors, fmt.Errorf("%s: %w", name, err))
-		} else {
-			installed = append(installed, name)
+// swapDir moves newDir into place at dir, replacing any existing directory
+// only once the new one is ready
+func swapDir(newDir, dir string) error {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		if err := os.Rename(newDir, dir); err != nil {
+			return fmt.Errorf("failed to move skill into place: %w", err)
 		}
+		return nil
 	}
-	return
+
+	oldDir := filepath.Join(filepath.Dir(dir), "."+filepath.Base(dir)+".old")
+	if err := os.RemoveAll(oldDir); err != nil {
+		return fmt.Errorf("failed to clean old directory: %w", err)
+	}
+	if err := os.Rename(dir, oldDir); err != nil {
+		return fmt.Errorf("failed to move existing skill aside: %w", err)
+	}
+	if err := os.Rename(newDir, dir); err != nil {
+		_ = os.Rename(oldDir, dir)
+		return fmt.Errorf("failed to move skill into place: %w", err)
+	}
+
+	return os.RemoveAll(oldDir)
+}
+
+// PlannedWrite describes a file that Install would write
+type PlannedWrite struct {
+	Path   string // Absolute path of the file
+	Exists bool   // Whether the file already exists and would be overwritten
+}
+
+// InstallDryRun returns the files Install would write for a skill without touching disk
+func (i *Installer) InstallDryRun(skillName string) ([]PlannedWrite, error) {
+	skill, err := i.findSkill(skillName)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := i.provider.GetFiles(skill)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch skill files: %w", err)
+	}
+	only, err := i.onlyFilesFor(i.SkillsDir(), skill.Name)
+	if err != nil {
+		return nil, err
+	}
+	files = filterContents(files, only)
+	if err := ValidateSkill(skill, files); err != nil {
+		return nil, fmt.Errorf("invalid skill: %w", err)
+	}
+
+	skillDir, err := filepath.Abs(filepath.Join(i.SkillsDir(), skill.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	plan := make([]PlannedWrite, 0, len(files))
+	for relPath := range files {
+		fullPath := filepath.Join(skillDir, relPath)
+		_, err := os.Stat(fullPath)
+		plan = append(plan, PlannedWrite{Path: fullPath, Exists: err == nil})
+	}
+	sort.Slice(plan, func(a, b int) bool { return plan[a].Path < plan[b].Path })
+
+	return plan, nil
+}
+
+func (i *Installer) InstallMultiple(skillNames []string) (installed []string, errors []error) {
+	return splitResults(i.InstallMultipleResults(skillNames), true)
 }
 
 func (i *Installer) InstallStack(stack string) (installed []string, errors []error) {
-	skills, err := i.provider.ListByStack(stack)
+	results, err := i.InstallStackResults(stack)
 	if err != nil {
-		errors = append(errors, fmt.Errorf("failed to list stack %s: %w", stack, err))
-		return
+		return nil, []error{err}
 	}
-	if len(skills) == 0 {
-		errors = append(errors, fmt.Errorf("no skills found in stack: %s", stack))
-		return
+	return splitResults(results, false)
+}
+
+func (i *Installer) InstallStacks(stacks []string) (installed []string, errors []error) {
+	results, err := i.InstallStacksResults(stacks)
+	if err != nil {
+		return nil, []error{err}
+	}
+	return splitResults(results, false)
+}
+
+func (i *Installer) InstallAll() (installed []string, errors []error) {
+	return i.InstallAllContext(context.Background())
+}
+
+// InstallAllContext is InstallAll with a deadline: once ctx is done, no more
+// skills are started and those left are reported as failed with ctx's error.
+// Skills already being installed are finished.
+func (i *Installer) InstallAllContext(ctx context.Context) (installed []string, errors []error) {
+	results, err := i.InstallAllResultsContext(ctx)
+	if err != nil {
+		return nil, []error{err}
+	}
+	return splitResults(results, false)
+}
+
+// installConcurrently installs names and their dependencies using up to
+// maxParallel workers. Results are reported in input order regardless of
+// completion order, with dependencies ahead of the skills requiring them.
+// Names whose dependencies cannot be resolved are reported first.
+func (i *Installer) installConcurrently(ctx context.Context, names []string) []InstallResult {
+	names, results := i.expandDependencies(names)
+
+	var txn *transaction
+	if i.atomic {
+		// A skill that cannot even be resolved fails the batch before anything is written
+		if hasFailure(results) {
+			for _, name := range names {
+				results = append(results, InstallResult{Name: name, Status: StatusFailed, Err: fmt.Errorf("not installed: %w", ErrBatchFailed)})
+			}
+			return results
+		}
+		txn = newTransaction()
+	}
+
+	written := make([]int, len(names))
+	sizes := make([]int64, len(names))
+	errs := make([]error, len(names))
+
+	workers := i.maxParallel
+	if workers < 1 {
+		workers = 1
+	}
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+
+	for idx, name := range names {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if err := ctx.Err(); err != nil {
+			errs[idx] = fmt.Errorf("not started: %w", err)
+			continue
+		}
+
+		wg.Add(1)
+		go func(idx int, name string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			written[idx], sizes[idx], errs[idx] = i.installFiles(i.SkillsDir(), name, i.force, nil, txn)
+		}(idx, name)
+	}
+	wg.Wait()
+
+	for idx, name := range names {
+		if errs[idx] != nil {
+			results = append(results, InstallResult{Name: name, Status: StatusFailed, Err: errs[idx]})
+		} else {
+			results = append(results, InstallResult{Name: name, Status: StatusInstalled, FilesWritten: written[idx], BytesWritten: sizes[idx]})
+		}
 	}
 
+	if txn != nil {
+		if hasFailure(results) {
+			return i.rollbackResults(txn, results)
+		}
+		txn.commit()
+	}
+	return results
+}
+
+func skillNames(skills []registry.Skill) []string {
+	names := make([]string, 0, len(skills))
 	for _, skill := range skills {
-		if err := i.Install(skill.Name); err != nil {
-			errors = append(errors, err)
+		names = append(names, skill.Name)
+	}
+	return names
+}
+
+// Remove deletes an installed skill, accepting any name ResolveInstalledName does
+func (i *Installer) Remove(skillName string) error {
+	skillName, err := i.ResolveInstalledName(skillName)
+	if err != nil {
+		return err
+	}
+	skillsDir, err := i.findSkillsDir(skillName)
+	if err != nil {
+		return err
+	}
+	if skillsDir == "" {
+		return fmt.Errorf("%w: %s", ErrNotInstalled, skillName)
+	}
+
+	if err := os.RemoveAll(filepath.Join(skillsDir, skillName)); err != nil {
+		return err
+	}
+
+	return i.updateLock(skillsDir, func(lock *Lock) {
+		delete(lock.Skills, skillName)
+	})
+}
+
+// findSkillsDir returns the skills directory holding a directory for skillName,
+// preferring the project over the global scope. Unlike InstalledScope it does
+// not require a SKILL.md, so partial installs are found too. It returns "" if
+// no scope has the directory.
+func (i *Installer) findSkillsDir(skillName string) (string, error) {
+	if err := ValidateSkillName(skillName); err != nil {
+		return "", err
+	}
+	for _, scope := range i.searchScopes() {
+		dir := i.dirFor(scope)
+		if dir == "" {
+			continue
+		}
+		info, err := os.Stat(filepath.Join(dir, skillName))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to check skill: %w", err)
+		}
+		if info.IsDir() {
+			return dir, nil
+		}
+	}
+	return "", nil
+}
+
+// RemoveMultiple removes each named skill, reporting skills that are not
+// installed as errors. Removed skills are listed by their installed names.
+func (i *Installer) RemoveMultiple(skillNames []string) (removed []string, errors []error) {
+	for _, name := range skillNames {
+		resolved, err := i.ResolveInstalledName(name)
+		if err == nil {
+			err = i.Remove(resolved)
+		}
+		if err != nil {
+			errors = append(errors, fmt.Errorf("%s: %w", name, err))
 		} else {
-			installed = append(installed, skill.Name)
+			removed = append(removed, resolved)
 		}
 	}
 	return
 }
 
-func (i *Installer) InstallAll() (installed []string, errors []error) {
-	skills, err := i.provider.List()
+// RemoveStack removes every installed skill belonging to stack. Skills of the
+// stack that are not installed are ignored.
+func (i *Installer) RemoveStack(stack string) (removed []string, errors []error) {
+	skills, err := i.provider.ListByStack(stack)
 	if err != nil {
-		errors = append(errors, fmt.Errorf("failed to list skills: %w", err))
+		errors = append(errors, fmt.Errorf("failed to list stack %s: %w", stack, err))
+		return
+	}
+	if len(skills) == 0 {
+		errors = append(errors, fmt.Errorf("no skills found in stack: %s", stack))
 		return
 	}
 
 	for _, skill := range skills {
-		if err := i.Install(skill.Name); err != nil {
-			errors = append(errors, err)
+		if !i.IsInstalled(skill.Name) {
+			continue
+		}
+		if err := i.Remove(skill.Name); err != nil {
+			errors = append(errors, fmt.Errorf("%s: %w", skill.Name, err))
 		} else {
-			installed = append(installed, skill.Name)
+			removed = append(removed, skill.Name)
 		}
 	}
 	return
 }
 
-func (i *Installer) Remove(skillName string) error {
-	dirPath := filepath.Join(i.baseDir, TargetDir, skillName)
+// ListInstalled returns installed skills across the searched scopes, listing
+// each name once with project-local skills taking precedence
+func (i *Installer) ListInstalled() ([]string, error) {
+	installed := []string{}
+	seen := make(map[string]bool)
 
-	// Check if skill directory exists
-	info, err := os.Stat(dirPath)
-	if os.IsNotExist(err) {
-		return fmt.Errorf("skill not installed: %s", skillName)
-	}
-	if err != nil {
-		return fmt.Errorf("failed to check skill: %w", err)
-	}
-	if !info.IsDir() {
-		return fmt.Errorf("skill not installed: %s", skillName)
+	for _, scope := range i.searchScopes() {
+		names, err := listInstalledIn(i.dirFor(scope))
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			if !seen[name] {
+				seen[name] = true
+				installed = append(installed, name)
+			}
+		}
 	}
 
-	return os.RemoveAll(dirPath)
+	return installed, nil
 }
 
-func (i *Installer) ListInstalled() ([]string, error) {
-	targetDir := filepath.Join(i.baseDir, TargetDir)
-
+// listInstalledIn lists skill directories containing a SKILL.md under targetDir
+func listInstalledIn(targetDir string) ([]string, error) {
 	entries, err := os.ReadDir(targetDir)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -141,6 +781,10 @@ func (i *Installer) ListInstalled() ([]string, error) {
 
 	var installed []string
 	for _, entry := range entries {
+		// Hidden entries are backups and temp directories, not skills
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
 		if entry.IsDir() {
 			// Skill directory: check for SKILL.md inside
 			skillMd := filepath.Join(targetDir, entry.Name(), "SKILL.md")
@@ -154,7 +798,16 @@ func (i *Installer) ListInstalled() ([]string, error) {
 }
 
 func (i *Installer) IsInstalled(skillName string) bool {
-	dirPath := filepath.Join(i.baseDir, TargetDir, skillName)
+	_, ok := i.InstalledScope(skillName)
+	return ok
+}
+
+func isInstalledIn(targetDir, skillName string) bool {
+	if targetDir == "" {
+		return false
+	}
+
+	dirPath := filepath.Join(targetDir, skillName)
 	info, err := os.Stat(dirPath)
 	if err != nil || !info.IsDir() {
 		return false
@@ -166,34 +819,220 @@ func (i *Installer) IsInstalled(skillName string) bool {
 	return err == nil
 }
 
-func (i *Installer) Update(skillName string) error {
-	if !i.IsInstalled(skillName) {
-		return fmt.Errorf("skill not installed: %s", skillName)
+// ErrLocallyModified is returned by Update when installed files were edited
+// since installation and force was not set
+var ErrLocallyModified = errors.New("skill has local modifications")
+
+// ErrUpToDate is returned by Update when the registry version of a skill is
+// the one already installed
+var ErrUpToDate = errors.New("skill is up to date")
+
+// Update reinstalls a skill from the registry if its files changed since it
+// was installed, returning ErrUpToDate otherwise. Skills whose files differ
+// from what was installed are left untouched unless force is set, in which
+// case they are restored even if the registry version is unchanged.
+func (i *Installer) Update(skillName string, force bool) error {
+	if err := ValidateSkillName(skillName); err != nil {
+		return err
+	}
+	scope, ok := i.InstalledScope(skillName)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNotInstalled, skillName)
+	}
+	skillsDir := i.dirFor(scope)
+
+	// Hold the skill from the change check until the backup is gone, so
+	// parallel updates and installs never share its directory
+	unlock := i.lockSkill(skillsDir, skillName)
+	defer unlock()
+
+	modified, err := i.IsModified(skillName)
+	if err != nil {
+		return fmt.Errorf("failed to check for local changes: %w", err)
+	}
+	if modified && !force {
+		return ErrLocallyModified
+	}
+
+	skill, err := i.findSkill(skillName)
+	if err != nil {
+		return err
+	}
+	files, err := i.provider.GetSkillFiles(skill)
+	if err != nil {
+		return fmt.Errorf("failed to fetch skill files: %w", err)
 	}
 
-	// Remove old and install new
-	if err := i.Remove(skillName); err != nil {
-		return fmt.Errorf("failed to remove old skill: %w", err)
+	if !modified {
+		_, installedHash, err := i.installedHash(skillsDir, skillName)
+		if err != nil {
+			return err
+		}
+		only, err := i.onlyFilesFor(skillsDir, skillName)
+		if err != nil {
+			return err
+		}
+		crlf, err := i.crlfFor(skillsDir, skillName)
+		if err != nil {
+			return err
+		}
+		contents := fileContents(files)
+		if crlf {
+			contents = crlfContents(files)
+		}
+		contents = filterContents(contents, only)
+		if hashFiles(contents) == installedHash {
+			return ErrUpToDate
+		}
 	}
 
-	return i.Install(skillName)
+	skillDir := filepath.Join(skillsDir, skillName)
+	backupDir := filepath.Join(skillsDir, "."+skillName+".bak")
+
+	// Clean up a backup left behind by an interrupted update
+	if err := os.RemoveAll(backupDir); err != nil {
+		return fmt.Errorf("failed to remove stale backup: %w", err)
+	}
+
+	// Move the old skill aside so it can be restored if the install fails
+	if err := os.Rename(skillDir, backupDir); err != nil {
+		return fmt.Errorf("failed to back up old skill: %w", err)
+	}
+
+	i.emit(InstallEvent{Skill: skillName, Phase: PhaseResolving})
+	_, _, err = i.installLocked(skillsDir, skillName, skill, force, files, nil)
+	i.emitResult(skillName, err)
+	if err != nil {
+		if restoreErr := restoreBackup(backupDir, skillDir); restoreErr != nil {
+			return fmt.Errorf("%w (restoring previous version failed: %v)", err, restoreErr)
+		}
+		return err
+	}
+
+	return os.RemoveAll(backupDir)
+}
+
+// restoreBackup replaces whatever is at skillDir with the backup directory
+func restoreBackup(backupDir, skillDir string) error {
+	if err := os.RemoveAll(skillDir); err != nil {
+		return err
+	}
+	return os.Rename(backupDir, skillDir)
+}
+
+// IsModified reports whether an installed skill's files differ from the hash
+// recorded in the lockfile. Skills without a lock entry are treated as unmodified.
+func (i *Installer) IsModified(skillName string) (bool, error) {
+	scope, ok := i.InstalledScope(skillName)
+	if !ok {
+		return false, nil
+	}
+	skillsDir := i.dirFor(scope)
+
+	lock, err := readLock(skillsDir)
+	if err != nil {
+		return false, err
+	}
+	entry, ok := lock.Skills[skillName]
+	if !ok || entry.Hash == "" {
+		return false, nil
+	}
+
+	files, err := readDirFiles(filepath.Join(skillsDir, skillName))
+	if err != nil {
+		return false, err
+	}
+	return hashFiles(files) != entry.Hash, nil
+}
+
+// readDirFiles reads every regular file under dir keyed by its slash-separated
+// relative path, leaving out the skill manifest
+func readDirFiles(dir string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if rel == ManifestFileName {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	return files, err
+}
+
+// UpdateAll updates every installed skill that changed in the registry,
+// skipping locally modified ones unless force is set
+func (i *Installer) UpdateAll(force bool) (updated, current, skipped []string, errs []error) {
+	return i.UpdateAllContext(context.Background(), force)
 }
 
-func (i *Installer) UpdateAll() (updated []string, errors []error) {
+// UpdateAllContext is UpdateAll with a deadline: once ctx is done, the skills
+// not yet updated are reported as failed with ctx's error. Up to maxParallel
+// skills are updated at once, each with its own backup, and every list is
+// sorted by name.
+func (i *Installer) UpdateAllContext(ctx context.Context, force bool) (updated, current, skipped []string, errs []error) {
 	installed, err := i.ListInstalled()
 	if err != nil {
-		errors = append(errors, err)
+		errs = append(errs, err)
 		return
 	}
 
 	if len(installed) == 0 {
 		return
 	}
+	sort.Strings(installed)
 
-	for _, name := range installed {
-		if err := i.Update(name); err != nil {
-			errors = append(errors, fmt.Errorf("%s: %w", name, err))
-		} else {
+	results := make([]error, len(installed))
+	workers := i.maxParallel
+	if workers < 1 {
+		workers = 1
+	}
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+
+	for idx, name := range installed {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if err := ctx.Err(); err != nil {
+			results[idx] = fmt.Errorf("not started: %w", err)
+			continue
+		}
+
+		wg.Add(1)
+		go func(idx int, name string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[idx] = i.Update(name, force)
+		}(idx, name)
+	}
+	wg.Wait()
+
+	for idx, name := range installed {
+		err := results[idx]
+		switch {
+		case errors.Is(err, ErrUpToDate):
+			current = append(current, name)
+		case errors.Is(err, ErrLocallyModified):
+			skipped = append(skipped, name)
+		case err != nil:
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		default:
 			updated = append(updated, name)
 		}
 	}
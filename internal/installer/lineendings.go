@@ -0,0 +1,67 @@
+package installer
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"unicode/utf8"
+
+	"github.com/cuongtl1992/vibe-skills/internal/registry"
+)
+
+// SetCRLF controls whether Install writes text files with CRLF line endings,
+// as many Windows editors expect. Binary and executable files are written as
+// they are. The choice is recorded in the lockfile and reused by updates and
+// by installs that do not call SetCRLF.
+func (i *Installer) SetCRLF(crlf bool) {
+	i.crlf = &crlf
+}
+
+// crlfFor reports whether a skill's text files are installed with CRLF line
+// endings: as set with SetCRLF, or else as recorded by its previous install
+func (i *Installer) crlfFor(skillsDir, skillName string) (bool, error) {
+	if i.crlf != nil {
+		return *i.crlf, nil
+	}
+	lock, err := readLock(skillsDir)
+	if err != nil {
+		return false, fmt.Errorf("failed to read lockfile: %w", err)
+	}
+	return lock.Skills[skillName].CRLF, nil
+}
+
+// convertsToCRLF reports whether a file is text to be written with CRLF line
+// endings. Scripts keep LF so their interpreter line still works.
+func convertsToCRLF(mode os.FileMode, content []byte) bool {
+	if mode&0111 != 0 || bytes.HasPrefix(content, []byte("#!")) {
+		return false
+	}
+	return utf8.Valid(content) && !bytes.ContainsRune(content, 0)
+}
+
+// toCRLF converts the bare LF line endings of content to CRLF
+func toCRLF(content []byte) []byte {
+	var out bytes.Buffer
+	out.Grow(len(content) + bytes.Count(content, []byte("\n")))
+	for idx, b := range content {
+		if b == '\n' && (idx == 0 || content[idx-1] != '\r') {
+			out.WriteByte('\r')
+		}
+		out.WriteByte(b)
+	}
+	return out.Bytes()
+}
+
+// crlfContents returns a skill's relative path -> content map with its text
+// files converted as Install would write them, judged by the same modes
+func crlfContents(files map[string]registry.SkillFile) map[string][]byte {
+	converted := make(map[string][]byte, len(files))
+	for relPath, file := range files {
+		content := file.Content
+		if convertsToCRLF(file.Mode, content) {
+			content = toCRLF(content)
+		}
+		converted[relPath] = content
+	}
+	return converted
+}
@@ -0,0 +1,150 @@
+package installer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/cuongtl1992/vibe-skills/internal/logging"
+)
+
+const (
+	LockFileName = ".vibe-lock.json"
+	lockVersion  = 1
+)
+
+// Lock records where each installed skill came from
+type Lock struct {
+	Version int                  `json:"version"`
+	Skills  map[string]LockEntry `json:"skills"`
+}
+
+// LockEntry describes a single installed skill
+type LockEntry struct {
+	Stack       string    `json:"stack,omitempty"`
+	Ref         string    `json:"ref"`
+	InstalledAt time.Time `json:"installed_at"`
+	Hash        string    `json:"hash"`            // SHA256 over all installed files
+	Files       []string  `json:"files,omitempty"` // Relative paths written by the install
+	Only        []string  `json:"only,omitempty"`  // File patterns the install was limited to
+	CRLF        bool      `json:"crlf,omitempty"`  // Text files were written with CRLF line endings
+}
+
+// ReadLock loads the lockfile for the current scope, returning an empty lock if none exists yet
+func (i *Installer) ReadLock() (*Lock, error) {
+	return readLock(i.SkillsDir())
+}
+
+// ReadLockFor loads the lockfile of the given scope
+func (i *Installer) ReadLockFor(scope Scope) (*Lock, error) {
+	return readLock(i.dirFor(scope))
+}
+
+func readLock(skillsDir string) (*Lock, error) {
+	data, err := os.ReadFile(lockPath(skillsDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Lock{Version: lockVersion, Skills: map[string]LockEntry{}}, nil
+		}
+		return nil, err
+	}
+
+	var lock Lock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, err
+	}
+	if lock.Skills == nil {
+		lock.Skills = map[string]LockEntry{}
+	}
+	return &lock, nil
+}
+
+// updateLock applies fn to the lockfile in skillsDir and writes it back atomically
+func (i *Installer) updateLock(skillsDir string, fn func(lock *Lock)) error {
+	i.lockMu.Lock()
+	defer i.lockMu.Unlock()
+
+	lock, err := readLock(skillsDir)
+	if err != nil {
+		return err
+	}
+	fn(lock)
+	lock.Version = lockVersion
+
+	data, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := lockPath(skillsDir)
+	logging.Debugf("update %s", path)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	// Write to a temp file and rename so readers never see a partial lockfile
+	tmp, err := os.CreateTemp(filepath.Dir(path), LockFileName+".*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// sortedPaths returns the keys of files in sorted order
+func sortedPaths(files map[string][]byte) []string {
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, filepath.ToSlash(p))
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func lockPath(skillsDir string) string {
+	return filepath.Join(skillsDir, LockFileName)
+}
+
+// hashFiles returns a stable SHA256 digest over a skill's relative paths and contents
+func hashFiles(files map[string][]byte) string {
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	h := sha256.New()
+	for _, p := range paths {
+		h.Write([]byte(filepath.ToSlash(p)))
+		h.Write([]byte{0})
+		h.Write(files[p])
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// restoreLockEntry returns an updateLock change putting back a skill's
+// previous lockfile entry, or removing it when there was none
+func restoreLockEntry(skillName string, entry *LockEntry) func(lock *Lock) {
+	return func(lock *Lock) {
+		if entry != nil {
+			lock.Skills[skillName] = *entry
+		} else {
+			delete(lock.Skills, skillName)
+		}
+	}
+}
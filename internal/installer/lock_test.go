@@ -0,0 +1,103 @@
+package installer
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+)
+
+func TestReadLock(t *testing.T) {
+	dir := t.TempDir()
+
+	lock, err := readLock(dir)
+	if err != nil {
+		t.Fatalf("readLock without a lockfile: %v", err)
+	}
+	if lock.Version != lockVersion || lock.Skills == nil {
+		t.Errorf("readLock without a lockfile = %+v, want an empty lock", lock)
+	}
+
+	if err := os.WriteFile(lockPath(dir), []byte(`{"version": 1}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if lock, err := readLock(dir); err != nil || lock.Skills == nil {
+		t.Errorf("readLock without skills = %+v, %v, want an empty skills map", lock, err)
+	}
+
+	if err := os.WriteFile(lockPath(dir), []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readLock(dir); err == nil {
+		t.Error("readLock of a corrupt lockfile succeeded")
+	}
+}
+
+func TestUpdateLockConcurrent(t *testing.T) {
+	inst, skillsDir := newTestInstaller(t, newFakeProvider())
+
+	var wg sync.WaitGroup
+	for n := 0; n < 16; n++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			err := inst.updateLock(skillsDir, func(lock *Lock) {
+				lock.Skills[fmt.Sprintf("skill-%d", n)] = LockEntry{Ref: "main"}
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}(n)
+	}
+	wg.Wait()
+
+	lock, err := readLock(skillsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lock.Skills) != 16 {
+		t.Errorf("lockfile has %d entries after 16 concurrent updates, want 16", len(lock.Skills))
+	}
+
+	entries, err := os.ReadDir(skillsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("skills directory holds %d files, want only the lockfile", len(entries))
+	}
+}
+
+func TestRestoreLockEntry(t *testing.T) {
+	lock := &Lock{Skills: map[string]LockEntry{"a": {Ref: "new"}, "b": {Ref: "new"}}}
+
+	restoreLockEntry("a", &LockEntry{Ref: "old"})(lock)
+	restoreLockEntry("b", nil)(lock)
+
+	if got := lock.Skills["a"].Ref; got != "old" {
+		t.Errorf("restored entry has ref %q, want old", got)
+	}
+	if _, ok := lock.Skills["b"]; ok {
+		t.Error("entry without a previous one was not removed")
+	}
+}
+
+func TestHashFiles(t *testing.T) {
+	base := map[string][]byte{"SKILL.md": []byte("a"), "references/x.md": []byte("b")}
+
+	if hashFiles(base) != hashFiles(map[string][]byte{"references/x.md": []byte("b"), "SKILL.md": []byte("a")}) {
+		t.Error("hash depends on map order")
+	}
+
+	differ := map[string]map[string][]byte{
+		"content":  {"SKILL.md": []byte("a"), "references/x.md": []byte("c")},
+		"path":     {"SKILL.md": []byte("a"), "references/y.md": []byte("b")},
+		"added":    {"SKILL.md": []byte("a"), "references/x.md": []byte("b"), "z.md": nil},
+		"boundary": {"SKILL.mda": nil, "references/x.md": []byte("b")},
+	}
+	for name, files := range differ {
+		if hashFiles(files) == hashFiles(base) {
+			t.Errorf("%s change keeps the hash", name)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package installer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DriftStatus classifies how the skills directory differs from its lockfile
+type DriftStatus string
+
+const (
+	DriftMissing  DriftStatus = "missing"  // In the lockfile but not on disk
+	DriftExtra    DriftStatus = "extra"    // On disk but not in the lockfile
+	DriftModified DriftStatus = "modified" // Content differs from what was installed
+)
+
+// LockDrift describes a skill, or a file of one, that does not match the lockfile
+type LockDrift struct {
+	Skill  string
+	Path   string // Relative to the skill directory, "" for the skill as a whole
+	Status DriftStatus
+}
+
+// CheckLock compares the skills directory of the current scope with its
+// lockfile without changing either: every locked skill must be present with
+// exactly the files it was installed with and their original content, and
+// no other skill may be present. It returns the differences, sorted.
+func (i *Installer) CheckLock() ([]LockDrift, error) {
+	skillsDir := i.SkillsDir()
+	if skillsDir == "" {
+		return nil, fmt.Errorf("cannot determine install directory")
+	}
+
+	lock, err := readLock(skillsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lockfile: %w", err)
+	}
+
+	var drift []LockDrift
+	for _, name := range sortedLockNames(lock) {
+		skillDrift, err := checkLockedSkill(filepath.Join(skillsDir, name), name, lock.Skills[name])
+		if err != nil {
+			return nil, fmt.Errorf("failed to check %s: %w", name, err)
+		}
+		drift = append(drift, skillDrift...)
+	}
+
+	entries, err := os.ReadDir(skillsDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, entry := range entries {
+		// Hidden entries are backups and temp directories, not skills
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		if _, ok := lock.Skills[entry.Name()]; !ok {
+			drift = append(drift, LockDrift{Skill: entry.Name(), Status: DriftExtra})
+		}
+	}
+
+	sort.Slice(drift, func(a, b int) bool {
+		if drift[a].Skill != drift[b].Skill {
+			return drift[a].Skill < drift[b].Skill
+		}
+		return drift[a].Path < drift[b].Path
+	})
+	return drift, nil
+}
+
+// checkLockedSkill compares one skill directory with its lockfile entry. The
+// manifest gives per-file hashes; the lockfile hash catches a manifest that
+// was edited along with the files.
+func checkLockedSkill(skillDir, name string, entry LockEntry) ([]LockDrift, error) {
+	files, err := readDirFiles(skillDir)
+	if os.IsNotExist(err) {
+		return []LockDrift{{Skill: name, Status: DriftMissing}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := readManifest(skillDir)
+	if err != nil && !errors.Is(err, ErrNoManifest) {
+		return nil, err
+	}
+
+	expected := entry.Files
+	if len(expected) == 0 && manifest != nil {
+		expected = make([]string, 0, len(manifest.Files))
+		for path := range manifest.Files {
+			expected = append(expected, path)
+		}
+	}
+
+	var drift []LockDrift
+	recorded := make(map[string]bool, len(expected))
+	for _, path := range expected {
+		recorded[path] = true
+		content, ok := files[path]
+		switch {
+		case !ok:
+			drift = append(drift, LockDrift{Skill: name, Path: path, Status: DriftMissing})
+		case manifest != nil && manifest.Files[path] != "" && manifest.Files[path] != hashContent(content):
+			drift = append(drift, LockDrift{Skill: name, Path: path, Status: DriftModified})
+		}
+	}
+	if len(expected) > 0 {
+		for path := range files {
+			if !recorded[path] {
+				drift = append(drift, LockDrift{Skill: name, Path: path, Status: DriftExtra})
+			}
+		}
+	}
+
+	if len(drift) == 0 && entry.Hash != "" && hashFiles(files) != entry.Hash {
+		drift = append(drift, LockDrift{Skill: name, Status: DriftModified})
+	}
+	return drift, nil
+}
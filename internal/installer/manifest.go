@@ -0,0 +1,102 @@
+package installer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	ManifestFileName = ".vibe-manifest.json"
+	manifestVersion  = 1
+)
+
+// ErrNoManifest is returned by CheckIntegrity for skills installed before
+// manifests were written
+var ErrNoManifest = errors.New("skill has no manifest")
+
+// Manifest lists every file of an installed skill with its SHA256. It is
+// stored inside the skill directory so the skill can be checked on its own.
+type Manifest struct {
+	Version int               `json:"version"`
+	Skill   string            `json:"skill"`
+	Files   map[string]string `json:"files"` // Relative path -> hex SHA256
+}
+
+// newManifest records the hash of each installed file
+func newManifest(skillName string, files map[string][]byte) *Manifest {
+	m := &Manifest{Version: manifestVersion, Skill: skillName, Files: make(map[string]string, len(files))}
+	for path, content := range files {
+		m.Files[filepath.ToSlash(path)] = hashContent(content)
+	}
+	return m
+}
+
+func hashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func writeManifest(skillDir string, m *Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(skillDir, ManifestFileName), data, 0644)
+}
+
+func readManifest(skillDir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(skillDir, ManifestFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNoManifest
+		}
+		return nil, err
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	return &m, nil
+}
+
+// CheckIntegrity compares an installed skill against its manifest and returns
+// the relative paths of files that are missing or whose content changed, sorted
+func (i *Installer) CheckIntegrity(skillName string) ([]string, error) {
+	scope, ok := i.InstalledScope(skillName)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotInstalled, skillName)
+	}
+	return checkIntegrity(filepath.Join(i.dirFor(scope), skillName))
+}
+
+func checkIntegrity(skillDir string) ([]string, error) {
+	m, err := readManifest(skillDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var bad []string
+	for path, want := range m.Files {
+		content, err := os.ReadFile(filepath.Join(skillDir, filepath.FromSlash(path)))
+		if err != nil {
+			if os.IsNotExist(err) {
+				bad = append(bad, path)
+				continue
+			}
+			return nil, err
+		}
+		if hashContent(content) != want {
+			bad = append(bad, path)
+		}
+	}
+
+	sort.Strings(bad)
+	return bad, nil
+}
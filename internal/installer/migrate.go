@@ -0,0 +1,134 @@
+package installer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ErrInstalledInDestination means a skill being migrated is already
+// installed in the scope it is moved to
+var ErrInstalledInDestination = errors.New("skill already installed in destination scope")
+
+// Migrate moves an installed skill from the other scope into scope to, along
+// with its lockfile entry, and returns the scope it came from. A copy already
+// installed in to is replaced when overwrite is set; otherwise Migrate fails
+// with ErrInstalledInDestination and changes nothing.
+func (i *Installer) Migrate(skillName string, to Scope, overwrite bool) (Scope, error) {
+	from := ScopeProject
+	if to == ScopeProject {
+		from = ScopeGlobal
+	}
+	fromDir, toDir := i.dirFor(from), i.dirFor(to)
+	if fromDir == "" || toDir == "" || filepath.Clean(fromDir) == filepath.Clean(toDir) {
+		return from, fmt.Errorf("the project and global skills directories are the same")
+	}
+
+	if err := ValidateSkillName(skillName); err != nil {
+		return from, err
+	}
+	if !isInstalledIn(fromDir, skillName) {
+		return from, fmt.Errorf("%w: %s in the %s scope", ErrNotInstalled, skillName, from)
+	}
+	if isInstalledIn(toDir, skillName) && !overwrite {
+		return from, fmt.Errorf("%w: %s is installed in %s", ErrInstalledInDestination, skillName, toDir)
+	}
+
+	srcLock, err := readLock(fromDir)
+	if err != nil {
+		return from, fmt.Errorf("failed to read lockfile: %w", err)
+	}
+	entry, locked := srcLock.Skills[skillName]
+
+	// Copy next to the destination and swap it in, so a failure leaves the
+	// destination as it was; the directories may be on different filesystems
+	if err := os.MkdirAll(toDir, 0755); err != nil {
+		return from, fmt.Errorf("failed to create skills directory: %w", err)
+	}
+	srcDir := filepath.Join(fromDir, skillName)
+	staging := filepath.Join(toDir, "."+skillName+".migrate")
+	if err := os.RemoveAll(staging); err != nil {
+		return from, fmt.Errorf("failed to clean staging directory: %w", err)
+	}
+	if err := copyDir(srcDir, staging); err != nil {
+		_ = os.RemoveAll(staging)
+		return from, fmt.Errorf("failed to copy skill: %w", err)
+	}
+	if err := swapDir(staging, filepath.Join(toDir, skillName)); err != nil {
+		_ = os.RemoveAll(staging)
+		return from, err
+	}
+
+	err = i.updateLock(toDir, func(lock *Lock) {
+		if locked {
+			lock.Skills[skillName] = entry
+		} else {
+			delete(lock.Skills, skillName)
+		}
+	})
+	if err != nil {
+		return from, fmt.Errorf("failed to update lockfile: %w", err)
+	}
+
+	if err := os.RemoveAll(srcDir); err != nil {
+		return from, fmt.Errorf("failed to remove %s: %w", srcDir, err)
+	}
+	if err := i.updateLock(fromDir, func(lock *Lock) { delete(lock.Skills, skillName) }); err != nil {
+		return from, fmt.Errorf("failed to update lockfile: %w", err)
+	}
+	return from, nil
+}
+
+// copyDir copies the directory tree at src to dst, keeping file modes and
+// symlinks
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case d.Type().IsRegular():
+			return copyFileMode(path, target, info.Mode().Perm())
+		}
+		return nil
+	})
+}
+
+func copyFileMode(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,69 @@
+package installer
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// SetOnlyFiles limits Install to the skill files matching any of patterns,
+// using path.Match syntax against paths relative to the skill directory. A
+// pattern without a slash also matches base names, and a matching directory
+// selects everything below it. SKILL.md is always installed. The patterns are
+// recorded in the lockfile and reused by updates.
+func (i *Installer) SetOnlyFiles(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid file pattern %q: %w", pattern, err)
+		}
+	}
+	i.onlyFiles = patterns
+	return nil
+}
+
+// onlyFilesFor returns the file patterns for installing a skill: those set
+// with SetOnlyFiles, or else the ones recorded by its previous install
+func (i *Installer) onlyFilesFor(skillsDir, skillName string) ([]string, error) {
+	if len(i.onlyFiles) > 0 {
+		return i.onlyFiles, nil
+	}
+	lock, err := readLock(skillsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lockfile: %w", err)
+	}
+	return lock.Skills[skillName].Only, nil
+}
+
+// selectsFile reports whether relPath, slash-separated, is installed under patterns
+func selectsFile(relPath string, patterns []string) bool {
+	if len(patterns) == 0 || relPath == "SKILL.md" {
+		return true
+	}
+
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, path.Base(relPath)); ok && !strings.Contains(pattern, "/") {
+			return true
+		}
+		// Try the path itself and each parent directory
+		for p := relPath; p != "." && p != "/"; p = path.Dir(p) {
+			if ok, _ := path.Match(strings.TrimSuffix(pattern, "/"), p); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// filterContents returns the files of a relative path -> content map selected by patterns
+func filterContents(files map[string][]byte, patterns []string) map[string][]byte {
+	if len(patterns) == 0 {
+		return files
+	}
+	selected := make(map[string][]byte, len(files))
+	for relPath, content := range files {
+		if selectsFile(relPath, patterns) {
+			selected[relPath] = content
+		}
+	}
+	return selected
+}
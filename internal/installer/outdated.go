@@ -0,0 +1,103 @@
+package installer
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/cuongtl1992/vibe-skills/internal/registry"
+)
+
+// OutdatedSkill describes an installed skill whose registry version differs
+// from what was installed
+type OutdatedSkill struct {
+	Name         string
+	Scope        Scope
+	InstalledRef string     // Ref recorded in the lockfile, empty if unknown
+	AvailableRef string     // Ref the registry is read from
+	UpdatedAt    *time.Time // When the registry skill last changed, nil if unknown
+}
+
+// CheckOutdated compares the hash recorded when a skill was installed with
+// the registry's current files. It returns nil if the skill is up to date.
+// Skills installed from a local directory or Git URL are never outdated.
+func (i *Installer) CheckOutdated(skillName string) (*OutdatedSkill, error) {
+	scope, ok := i.InstalledScope(skillName)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotInstalled, skillName)
+	}
+	skillsDir := i.dirFor(scope)
+
+	entry, installedHash, err := i.installedHash(skillsDir, skillName)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Stack == registry.LocalStack || entry.Stack == registry.GitStack {
+		return nil, nil
+	}
+
+	skill, err := i.findSkill(skillName)
+	if err != nil {
+		return nil, err
+	}
+	skillFiles, err := i.provider.GetSkillFiles(skill)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch skill files: %w", err)
+	}
+
+	files := fileContents(skillFiles)
+	if entry.CRLF {
+		files = crlfContents(skillFiles)
+	}
+	files = filterContents(files, entry.Only)
+	if hashFiles(files) == installedHash {
+		return nil, nil
+	}
+	return &OutdatedSkill{
+		Name:         skillName,
+		Scope:        scope,
+		InstalledRef: entry.Ref,
+		AvailableRef: i.provider.GetRef(),
+		UpdatedAt:    skill.UpdatedAt,
+	}, nil
+}
+
+// installedHash returns a skill's lockfile entry and the hash of its files as
+// installed, falling back to the files on disk when no hash was recorded
+func (i *Installer) installedHash(skillsDir, skillName string) (LockEntry, string, error) {
+	lock, err := readLock(skillsDir)
+	if err != nil {
+		return LockEntry{}, "", fmt.Errorf("failed to read lockfile: %w", err)
+	}
+	entry := lock.Skills[skillName]
+	if entry.Hash != "" {
+		return entry, entry.Hash, nil
+	}
+
+	files, err := readDirFiles(filepath.Join(skillsDir, skillName))
+	if err != nil {
+		return entry, "", err
+	}
+	return entry, hashFiles(files), nil
+}
+
+// Outdated checks every installed skill and returns those with a newer
+// version in the registry
+func (i *Installer) Outdated() (outdated []OutdatedSkill, errs []error) {
+	installed, err := i.ListInstalled()
+	if err != nil {
+		errs = append(errs, err)
+		return
+	}
+
+	for _, name := range installed {
+		skill, err := i.CheckOutdated(name)
+		switch {
+		case err != nil:
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		case skill != nil:
+			outdated = append(outdated, *skill)
+		}
+	}
+	return
+}
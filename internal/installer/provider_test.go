@@ -0,0 +1,135 @@
+package installer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/cuongtl1992/vibe-skills/internal/registry"
+)
+
+// fakeProvider is an in-memory SkillProvider whose skills tests edit between
+// installs and updates
+type fakeProvider struct {
+	mu     sync.Mutex
+	skills map[string]registry.Skill
+	files  map[string]map[string]registry.SkillFile
+	fail   map[string]error // GetSkillFiles errors by skill name
+}
+
+func newFakeProvider() *fakeProvider {
+	return &fakeProvider{
+		skills: make(map[string]registry.Skill),
+		files:  make(map[string]map[string]registry.SkillFile),
+		fail:   make(map[string]error),
+	}
+}
+
+// addSkill registers a skill with a valid SKILL.md whose body is version
+func (p *fakeProvider) addSkill(name, version string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.skills[name] = registry.Skill{Name: name, Stack: "test", Path: "skills/test/" + name + "/SKILL.md"}
+	p.files[name] = map[string]registry.SkillFile{
+		"SKILL.md": {Content: []byte("---\nname: " + name + "\ndescription: test skill\n---\n" + version + "\n"), Mode: 0644},
+	}
+}
+
+// setFile adds or replaces one file of a skill
+func (p *fakeProvider) setFile(name, path, content string, mode os.FileMode) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.files[name][path] = registry.SkillFile{Content: []byte(content), Mode: mode}
+}
+
+func (p *fakeProvider) Find(name string) (*registry.Skill, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	skill, ok := p.skills[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSkillNotFound, name)
+	}
+	return &skill, nil
+}
+
+func (p *fakeProvider) List() ([]registry.Skill, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var skills []registry.Skill
+	for _, skill := range p.skills {
+		skills = append(skills, skill)
+	}
+	sort.Slice(skills, func(a, b int) bool { return skills[a].Name < skills[b].Name })
+	return skills, nil
+}
+
+func (p *fakeProvider) ListByStack(stack string) ([]registry.Skill, error) {
+	return p.List()
+}
+
+func (p *fakeProvider) ListByTag(tag string) ([]registry.Skill, error) {
+	return p.List()
+}
+
+func (p *fakeProvider) GetContent(skill *registry.Skill) ([]byte, error) {
+	files, err := p.GetSkillFiles(skill)
+	if err != nil {
+		return nil, err
+	}
+	return files["SKILL.md"].Content, nil
+}
+
+func (p *fakeProvider) GetFiles(skill *registry.Skill) (map[string][]byte, error) {
+	files, err := p.GetSkillFiles(skill)
+	if err != nil {
+		return nil, err
+	}
+	return fileContents(files), nil
+}
+
+// GetSkillFiles returns a copy, since installs modify the map they are given
+func (p *fakeProvider) GetSkillFiles(skill *registry.Skill) (map[string]registry.SkillFile, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.fail[skill.Name]; err != nil {
+		return nil, err
+	}
+	files := make(map[string]registry.SkillFile, len(p.files[skill.Name]))
+	for path, file := range p.files[skill.Name] {
+		files[path] = file
+	}
+	return files, nil
+}
+
+func (p *fakeProvider) GetRef() string {
+	return "main"
+}
+
+// newTestInstaller returns an installer for a fresh project with its own
+// home directory, and the project's skills directory
+func newTestInstaller(t *testing.T, provider SkillProvider) (*Installer, string) {
+	t.Helper()
+	root := t.TempDir()
+	t.Setenv("HOME", filepath.Join(root, "home"))
+	project := filepath.Join(root, "project")
+	if err := os.MkdirAll(project, 0755); err != nil {
+		t.Fatal(err)
+	}
+	return New(provider, project), filepath.Join(project, TargetDir)
+}
+
+// readSkillFile returns the content of one installed file, "" if it is missing
+func readSkillFile(t *testing.T, skillsDir, skill, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(skillsDir, skill, filepath.FromSlash(path)))
+	if os.IsNotExist(err) {
+		return ""
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
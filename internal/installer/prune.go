@@ -0,0 +1,46 @@
+package installer
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cuongtl1992/vibe-skills/internal/registry"
+)
+
+// Orphaned returns the skills installed in the current scope from a registry
+// that no longer lists them, sorted by name. Skills without a lockfile entry,
+// such as ones authored in place, and skills installed from a local directory
+// or Git URL are never orphaned.
+func (i *Installer) Orphaned() ([]string, error) {
+	skills, err := i.provider.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list skills: %w", err)
+	}
+	available := make(map[string]bool, len(skills))
+	for _, skill := range skills {
+		available[skill.Name] = true
+	}
+
+	skillsDir := i.SkillsDir()
+	names, err := listInstalledIn(skillsDir)
+	if err != nil {
+		return nil, err
+	}
+	lock, err := readLock(skillsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lockfile: %w", err)
+	}
+
+	orphaned := []string{}
+	for _, name := range names {
+		entry, tracked := lock.Skills[name]
+		if !tracked || entry.Stack == registry.LocalStack || entry.Stack == registry.GitStack {
+			continue
+		}
+		if !available[name] {
+			orphaned = append(orphaned, name)
+		}
+	}
+	sort.Strings(orphaned)
+	return orphaned, nil
+}
@@ -0,0 +1,121 @@
+package installer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrAmbiguousName means a name matches several installed skills; errors.As
+// with *AmbiguousNameError gives them
+var ErrAmbiguousName = errors.New("ambiguous skill name")
+
+// AmbiguousNameError reports the installed skills a name could refer to
+type AmbiguousNameError struct {
+	Name       string
+	Candidates []string
+}
+
+func (e *AmbiguousNameError) Error() string {
+	return fmt.Sprintf("%s matches %d installed skills, use one of: %s", e.Name, len(e.Candidates), strings.Join(e.Candidates, ", "))
+}
+
+// Is makes an AmbiguousNameError match ErrAmbiguousName
+func (e *AmbiguousNameError) Is(target error) bool {
+	return target == ErrAmbiguousName
+}
+
+// ResolveInstalledName returns the directory name of the installed skill
+// meant by name: name itself if a skill directory has it, or else the one
+// installed skill whose name matches ignoring case and -/_ differences, whose
+// stack/name it is, or whose SKILL.md frontmatter declares it
+func (i *Installer) ResolveInstalledName(name string) (string, error) {
+	nameErr := ValidateSkillName(name)
+	if nameErr == nil {
+		dir, err := i.findSkillsDir(name)
+		if err != nil {
+			return "", err
+		}
+		if dir != "" {
+			return name, nil
+		}
+	}
+
+	installed, err := i.ListInstalled()
+	if err != nil {
+		return "", err
+	}
+
+	var candidates []string
+	for _, skill := range installed {
+		if i.matchesInstalled(skill, name) {
+			candidates = append(candidates, skill)
+		}
+	}
+
+	switch {
+	case len(candidates) == 1:
+		return candidates[0], nil
+	case len(candidates) > 1:
+		sort.Strings(candidates)
+		return "", &AmbiguousNameError{Name: name, Candidates: candidates}
+	case nameErr != nil:
+		return "", nameErr
+	}
+	return "", fmt.Errorf("%w: %s", ErrNotInstalled, name)
+}
+
+// matchesInstalled reports whether name refers to the installed skill
+func (i *Installer) matchesInstalled(skill, name string) bool {
+	if normalizeSkillName(skill) == normalizeSkillName(name) {
+		return true
+	}
+
+	scope, ok := i.InstalledScope(skill)
+	if !ok {
+		return false
+	}
+	skillsDir := i.dirFor(scope)
+
+	if stack, base, ok := strings.Cut(name, "/"); ok && normalizeSkillName(base) == normalizeSkillName(skill) {
+		if lock, err := readLock(skillsDir); err == nil && strings.EqualFold(lock.Skills[skill].Stack, stack) {
+			return true
+		}
+	}
+
+	declared, ok := declaredName(filepath.Join(skillsDir, skill))
+	return ok && normalizeSkillName(declared) == normalizeSkillName(name)
+}
+
+// declaredName returns the name in the frontmatter of skillDir's SKILL.md
+func declaredName(skillDir string) (string, bool) {
+	content, err := os.ReadFile(filepath.Join(skillDir, "SKILL.md"))
+	if err != nil {
+		return "", false
+	}
+	frontmatter, err := extractFrontmatter(content)
+	if err != nil {
+		return "", false
+	}
+	var fm skillFrontmatter
+	if err := yaml.Unmarshal(frontmatter, &fm); err != nil || fm.Name == "" {
+		return "", false
+	}
+	return fm.Name, true
+}
+
+// declaresSkill reports whether skillDir's SKILL.md names skillName
+func declaresSkill(skillDir, skillName string) bool {
+	declared, ok := declaredName(skillDir)
+	return ok && declared == skillName
+}
+
+// normalizeSkillName folds case and treats _ and spaces like -
+func normalizeSkillName(name string) string {
+	return strings.NewReplacer("_", "-", " ", "-").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
@@ -0,0 +1,121 @@
+package installer
+
+import (
+	"context"
+	"fmt"
+)
+
+// InstallStatus is the outcome of installing a single skill
+type InstallStatus string
+
+const (
+	StatusInstalled InstallStatus = "installed"
+	StatusSkipped   InstallStatus = "skipped" // Already installed earlier in the same batch
+	StatusFailed    InstallStatus = "failed"
+)
+
+// InstallResult reports what happened to one skill of a batch install
+type InstallResult struct {
+	Name         string
+	Status       InstallStatus
+	Err          error // Failure cause, set for StatusFailed
+	FilesWritten int
+	BytesWritten int64
+	Stacks       []string // Stacks that selected the skill, set by InstallStacksResults
+}
+
+// InstallMultipleResults installs the named skills and their dependencies,
+// returning one result per skill
+func (i *Installer) InstallMultipleResults(skillNames []string) []InstallResult {
+	return i.InstallMultipleResultsContext(context.Background(), skillNames)
+}
+
+// InstallMultipleResultsContext is InstallMultipleResults with a deadline, as
+// for InstallAllContext
+func (i *Installer) InstallMultipleResultsContext(ctx context.Context, skillNames []string) []InstallResult {
+	return i.installConcurrently(ctx, skillNames)
+}
+
+// InstallStackResults installs every skill in stack, returning one result per skill
+func (i *Installer) InstallStackResults(stack string) ([]InstallResult, error) {
+	skills, err := i.provider.ListByStack(stack)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stack %s: %w", stack, err)
+	}
+	if len(skills) == 0 {
+		return nil, fmt.Errorf("no skills found in stack: %s", stack)
+	}
+
+	return i.installConcurrently(context.Background(), skillNames(skills)), nil
+}
+
+// InstallStacksResults installs the union of the skills in stacks, installing
+// a skill listed by several stacks once and recording which stacks listed it
+func (i *Installer) InstallStacksResults(stacks []string) ([]InstallResult, error) {
+	return i.InstallStacksResultsContext(context.Background(), stacks)
+}
+
+// InstallStacksResultsContext is InstallStacksResults with a deadline, as for
+// InstallAllContext
+func (i *Installer) InstallStacksResultsContext(ctx context.Context, stacks []string) ([]InstallResult, error) {
+	var names []string
+	contributors := make(map[string][]string)
+
+	for _, stack := range stacks {
+		skills, err := i.provider.ListByStack(stack)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list stack %s: %w", stack, err)
+		}
+		if len(skills) == 0 {
+			return nil, fmt.Errorf("no skills found in stack: %s", stack)
+		}
+
+		for _, skill := range skills {
+			if _, seen := contributors[skill.Name]; !seen {
+				names = append(names, skill.Name)
+			}
+			contributors[skill.Name] = append(contributors[skill.Name], stack)
+		}
+	}
+
+	results := i.installConcurrently(ctx, names)
+	for idx := range results {
+		results[idx].Stacks = contributors[results[idx].Name]
+	}
+	return results, nil
+}
+
+// InstallAllResults installs every skill in the registry, returning one result per skill
+func (i *Installer) InstallAllResults() ([]InstallResult, error) {
+	return i.InstallAllResultsContext(context.Background())
+}
+
+// InstallAllResultsContext is InstallAllResults with a deadline, as for
+// InstallAllContext
+func (i *Installer) InstallAllResultsContext(ctx context.Context) ([]InstallResult, error) {
+	skills, err := i.provider.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list skills: %w", err)
+	}
+
+	return i.installConcurrently(ctx, skillNames(skills)), nil
+}
+
+// splitResults converts results to the installed names and errors returned by
+// InstallMultiple and friends, optionally prefixing errors with the skill name.
+// Skipped skills appear in neither.
+func splitResults(results []InstallResult, prefixErrors bool) (installed []string, errors []error) {
+	for _, result := range results {
+		switch result.Status {
+		case StatusInstalled:
+			installed = append(installed, result.Name)
+		case StatusFailed:
+			if prefixErrors {
+				errors = append(errors, fmt.Errorf("%s: %w", result.Name, result.Err))
+			} else {
+				errors = append(errors, result.Err)
+			}
+		}
+	}
+	return
+}
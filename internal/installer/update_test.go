@@ -0,0 +1,120 @@
+package installer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestUpdateAllParallel(t *testing.T) {
+	provider := newFakeProvider()
+	var names []string
+	for n := 0; n < 8; n++ {
+		name := fmt.Sprintf("skill-%d", n)
+		names = append(names, name)
+		provider.addSkill(name, "v1")
+	}
+	inst, skillsDir := newTestInstaller(t, provider)
+	inst.SetMaxParallel(4)
+	if _, errs := inst.InstallMultiple(names); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+
+	for _, name := range names {
+		provider.addSkill(name, "v2")
+		provider.setFile(name, "references/notes.md", "notes for "+name, 0644)
+	}
+
+	// Installs of the same skills race with the updates
+	inst.SetForce(true)
+	var wg sync.WaitGroup
+	var updated []string
+	var errs []error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		updated, _, _, errs = inst.UpdateAll(false)
+	}()
+	for _, name := range names {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			if err := inst.Install(name); err != nil {
+				t.Errorf("Install(%s): %v", name, err)
+			}
+		}(name)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		t.Fatalf("UpdateAll errors: %v", errs)
+	}
+	for idx := 1; idx < len(updated); idx++ {
+		if updated[idx-1] > updated[idx] {
+			t.Errorf("updated skills are not sorted: %v", updated)
+		}
+	}
+
+	lock, err := readLock(skillsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range names {
+		if got := readSkillFile(t, skillsDir, name, "SKILL.md"); !strings.Contains(got, "v2") {
+			t.Errorf("%s: SKILL.md = %q, want v2", name, got)
+		}
+		if _, ok := lock.Skills[name]; !ok {
+			t.Errorf("%s: lockfile entry lost", name)
+		}
+		if modified, err := inst.IsModified(name); err != nil || modified {
+			t.Errorf("%s: IsModified = %v, %v, want the lockfile to match the files", name, modified, err)
+		}
+	}
+
+	entries, err := os.ReadDir(skillsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") && entry.IsDir() {
+			t.Errorf("left behind %s", entry.Name())
+		}
+	}
+}
+
+func TestUpdateRestoresBackupOnFailure(t *testing.T) {
+	provider := newFakeProvider()
+	provider.addSkill("flaky", "v1")
+	inst, skillsDir := newTestInstaller(t, provider)
+	if err := inst.Install("flaky"); err != nil {
+		t.Fatal(err)
+	}
+	before, err := readLock(skillsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A v2 that fails validation is only found once the old files are moved aside
+	provider.addSkill("flaky", "v2")
+	provider.setFile("flaky", "SKILL.md", "no frontmatter\n", 0644)
+	if err := inst.Update("flaky", false); err == nil {
+		t.Fatal("Update with an invalid SKILL.md succeeded")
+	}
+
+	if got := readSkillFile(t, skillsDir, "flaky", "SKILL.md"); !strings.Contains(got, "v1") {
+		t.Errorf("SKILL.md = %q, want v1 restored", got)
+	}
+	after, err := readLock(skillsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := after.Skills["flaky"], before.Skills["flaky"]; got.Hash != want.Hash || !got.InstalledAt.Equal(want.InstalledAt) {
+		t.Errorf("lockfile entry changed by a failed update: %+v, want %+v", got, want)
+	}
+	if _, err := os.Stat(filepath.Join(skillsDir, ".flaky.bak")); !os.IsNotExist(err) {
+		t.Error("backup left behind after restoring")
+	}
+}
@@ -0,0 +1,115 @@
+package installer
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cuongtl1992/vibe-skills/internal/registry"
+	"gopkg.in/yaml.v3"
+)
+
+// skillFrontmatter holds the SKILL.md frontmatter fields required by Claude Code
+type skillFrontmatter struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+}
+
+// ValidateSkill checks that the skill's name and file paths stay inside its
+// directory, and that its SKILL.md starts with YAML frontmatter declaring a
+// description and a name matching the skill
+func ValidateSkill(skill *registry.Skill, files map[string][]byte) error {
+	if err := ValidateSkillName(skill.Name); err != nil {
+		return err
+	}
+	for relPath := range files {
+		if _, err := containedPath(skill.Name, relPath); err != nil {
+			return err
+		}
+	}
+
+	content, ok := files["SKILL.md"]
+	if !ok {
+		return fmt.Errorf("SKILL.md is missing")
+	}
+
+	frontmatter, err := extractFrontmatter(content)
+	if err != nil {
+		return fmt.Errorf("SKILL.md: %w", err)
+	}
+
+	var fm skillFrontmatter
+	if err := yaml.Unmarshal(frontmatter, &fm); err != nil {
+		return fmt.Errorf("SKILL.md: invalid frontmatter: %w", err)
+	}
+
+	if fm.Name == "" {
+		return fmt.Errorf("SKILL.md: frontmatter is missing required field \"name\"")
+	}
+	if fm.Description == "" {
+		return fmt.Errorf("SKILL.md: frontmatter is missing required field \"description\"")
+	}
+	if fm.Name != skill.Name {
+		return fmt.Errorf("SKILL.md: frontmatter name %q does not match skill %q", fm.Name, skill.Name)
+	}
+
+	return nil
+}
+
+// extractFrontmatter returns the YAML between the leading "---" delimiters
+func extractFrontmatter(content []byte) ([]byte, error) {
+	content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+	if !bytes.HasPrefix(content, []byte("---\n")) {
+		return nil, fmt.Errorf("missing YAML frontmatter")
+	}
+
+	rest := content[len("---\n"):]
+	if bytes.HasPrefix(rest, []byte("---")) {
+		return nil, fmt.Errorf("frontmatter is empty")
+	}
+	end := bytes.Index(rest, []byte("\n---"))
+	if end < 0 {
+		return nil, fmt.Errorf("frontmatter is not terminated by \"---\"")
+	}
+
+	return rest[:end], nil
+}
+
+// ValidateSkillName checks that name can be used as a skill directory: a
+// single path element that is not "." or ".."
+func ValidateSkillName(name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("invalid skill name: empty")
+	case name == "." || name == "..", strings.ContainsAny(name, `/\`), filepath.VolumeName(name) != "", hasDriveLetter(name):
+		return fmt.Errorf("invalid skill name %q: must be a single directory name", name)
+	}
+	return nil
+}
+
+// containedPath joins dir and the slash- or OS-separated relative path rel,
+// rejecting absolute paths and ones that escape dir through ".."
+func containedPath(dir, rel string) (string, error) {
+	if rel == "" || filepath.IsAbs(rel) || strings.HasPrefix(rel, "/") || strings.HasPrefix(rel, `\`) || filepath.VolumeName(rel) != "" || hasDriveLetter(rel) {
+		return "", fmt.Errorf("invalid skill file path %q: must be relative", rel)
+	}
+
+	base := filepath.Clean(dir)
+	full := filepath.Join(base, filepath.FromSlash(rel))
+	if !strings.HasPrefix(full, base+string(os.PathSeparator)) {
+		return "", fmt.Errorf("invalid skill file path %q: escapes the skill directory", rel)
+	}
+	return full, nil
+}
+
+// hasDriveLetter reports whether s starts with a Windows drive such as "C:",
+// which filepath.VolumeName only recognizes when running on Windows
+func hasDriveLetter(s string) bool {
+	if len(s) < 2 || s[1] != ':' {
+		return false
+	}
+	c := s[0] | 0x20
+	return c >= 'a' && c <= 'z'
+}
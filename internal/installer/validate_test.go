@@ -0,0 +1,128 @@
+package installer
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateSkillName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"code-reviewer", false},
+		{"go_tests.v2", false},
+		{"", true},
+		{".", true},
+		{"..", true},
+		{"../../etc", true},
+		{"a/b", true},
+		{`a\b`, true},
+		{"/etc", true},
+		{`C:\skills`, true},
+		{"C:", true},
+		{"c:evil", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSkillName(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSkillName(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestContainedPath(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "skill")
+
+	tests := []struct {
+		rel     string
+		want    string
+		wantErr bool
+	}{
+		{"SKILL.md", "SKILL.md", false},
+		{"references/guide.md", filepath.Join("references", "guide.md"), false},
+		{"sub/../other.md", "other.md", false},
+		{"", "", true},
+		{"..", "", true},
+		{"../../etc/passwd", "", true},
+		{"/etc/passwd", "", true},
+		{`\etc\passwd`, "", true},
+		{"sub/../../x", "", true},
+		{`..\..\x`, "", runtimeUsesBackslash()},
+		{"C:/Windows/win.ini", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rel, func(t *testing.T) {
+			got, err := containedPath(dir, tt.rel)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("containedPath(%q) error = %v, wantErr %v", tt.rel, err, tt.wantErr)
+			}
+			if err == nil && tt.want != "" && got != filepath.Join(dir, tt.want) {
+				t.Errorf("containedPath(%q) = %q, want %q", tt.rel, got, filepath.Join(dir, tt.want))
+			}
+		})
+	}
+}
+
+// runtimeUsesBackslash reports whether \ separates paths on this OS; elsewhere
+// it is an ordinary file name character
+func runtimeUsesBackslash() bool {
+	return os.PathSeparator == '\\'
+}
+
+func TestInstallRejectsTraversalPaths(t *testing.T) {
+	paths := []string{
+		"../../etc/passwd",
+		"sub/../../../../outside.txt",
+		"/tmp/vibe-skills-absolute.txt",
+	}
+
+	for _, evil := range paths {
+		t.Run(evil, func(t *testing.T) {
+			root := t.TempDir()
+			t.Setenv("HOME", filepath.Join(root, "home"))
+			project := filepath.Join(root, "project")
+			if err := os.MkdirAll(project, 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			provider := newFakeProvider()
+			provider.addSkill("evil", "v1")
+			provider.setFile("evil", evil, "pwned", 0644)
+			inst := New(provider, project)
+
+			if err := inst.Install("evil"); err == nil {
+				t.Fatalf("Install with file %q succeeded, want an error", evil)
+			}
+
+			skillsDir := filepath.Join(project, TargetDir)
+			err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+				if err != nil || d.IsDir() {
+					return err
+				}
+				if !strings.HasPrefix(path, skillsDir+string(os.PathSeparator)) {
+					t.Errorf("wrote %s outside the skills directory", path)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if filepath.IsAbs(evil) {
+				if _, err := os.Stat(evil); err == nil {
+					t.Errorf("wrote absolute path %s", evil)
+				}
+			}
+			if inst.IsInstalled("evil") {
+				t.Error("skill with a traversal path was installed")
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package installer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Verify scans the searched skills directories for broken installs: skill
+// directories that hold files but no SKILL.md, typically left by an
+// interrupted install, and skills whose files no longer match their manifest
+// or the hash recorded in the lockfile. It returns the affected skill names, sorted.
+func (i *Installer) Verify() ([]string, error) {
+	seen := make(map[string]bool)
+	var broken []string
+
+	for _, scope := range i.searchScopes() {
+		names, err := verifyIn(i.dirFor(scope))
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			if !seen[name] {
+				seen[name] = true
+				broken = append(broken, name)
+			}
+		}
+	}
+
+	sort.Strings(broken)
+	return broken, nil
+}
+
+// verifyIn returns the broken skills in a single skills directory
+func verifyIn(skillsDir string) ([]string, error) {
+	if skillsDir == "" {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(skillsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	lock, err := readLock(skillsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lockfile: %w", err)
+	}
+
+	var broken []string
+	for _, entry := range entries {
+		// Hidden entries are backups and temp directories, not skills
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		files, err := readDirFiles(filepath.Join(skillsDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			continue
+		}
+
+		if _, ok := files["SKILL.md"]; !ok {
+			broken = append(broken, entry.Name())
+			continue
+		}
+		bad, err := checkIntegrity(filepath.Join(skillsDir, entry.Name()))
+		if err != nil && !errors.Is(err, ErrNoManifest) {
+			return nil, err
+		}
+		if len(bad) > 0 {
+			broken = append(broken, entry.Name())
+			continue
+		}
+		if lockEntry, ok := lock.Skills[entry.Name()]; ok && lockEntry.Hash != "" && hashFiles(files) != lockEntry.Hash {
+			broken = append(broken, entry.Name())
+		}
+	}
+	return broken, nil
+}
+
+// Repair reinstalls each named skill from the registry, discarding whatever
+// is currently in its directory
+func (i *Installer) Repair(skillNames []string) (repaired []string, errors []error) {
+	for _, name := range skillNames {
+		if err := i.Reinstall(name); err != nil {
+			errors = append(errors, fmt.Errorf("%s: %w", name, err))
+		} else {
+			repaired = append(repaired, name)
+		}
+	}
+	return
+}
+
+// CheckLayout inspects the searched skills directories for leftovers that do
+// not affect installed skills but indicate an interrupted operation: temp and
+// backup directories, and lockfile entries whose directory is gone. It returns
+// a description of each problem, and an error if a lockfile cannot be read.
+func (i *Installer) CheckLayout() ([]string, error) {
+	var problems []string
+
+	for _, scope := range i.searchScopes() {
+		skillsDir := i.dirFor(scope)
+		if skillsDir == "" {
+			continue
+		}
+
+		entries, err := os.ReadDir(skillsDir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			name := entry.Name()
+			if !entry.IsDir() || !strings.HasPrefix(name, ".") {
+				continue
+			}
+			for _, suffix := range []string{".tmp", ".old", ".bak"} {
+				if strings.HasSuffix(name, suffix) {
+					problems = append(problems, fmt.Sprintf("leftover directory %s", filepath.Join(skillsDir, name)))
+					break
+				}
+			}
+		}
+
+		lock, err := readLock(skillsDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read lockfile %s: %w", lockPath(skillsDir), err)
+		}
+		for _, name := range sortedLockNames(lock) {
+			if _, err := os.Stat(filepath.Join(skillsDir, name)); os.IsNotExist(err) {
+				problems = append(problems, fmt.Sprintf("lockfile lists %s (%s) but its directory is missing", name, scope))
+			}
+		}
+	}
+
+	return problems, nil
+}
+
+func sortedLockNames(lock *Lock) []string {
+	names := make([]string, 0, len(lock.Skills))
+	for name := range lock.Skills {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Level controls how much the CLI prints
+type Level int
+
+const (
+	// LevelQuiet prints errors only
+	LevelQuiet Level = iota
+	// LevelNormal prints progress and results
+	LevelNormal
+	// LevelVerbose also logs HTTP requests, cache hits and file writes
+	LevelVerbose
+	// LevelDebug also logs response details and internal decisions
+	LevelDebug
+)
+
+var (
+	mu     sync.Mutex
+	level            = LevelNormal
+	stdout io.Writer = os.Stdout
+	stderr io.Writer = os.Stderr
+)
+
+// SetLevel sets the level for all subsequent output
+func SetLevel(l Level) {
+	mu.Lock()
+	defer mu.Unlock()
+	level = l
+}
+
+// SetOutput redirects normal output and diagnostics, mainly for embedding
+func SetOutput(out, diag io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	stdout, stderr = out, diag
+}
+
+// Enabled reports whether messages at l are printed
+func Enabled(l Level) bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return level >= l
+}
+
+// Infof prints progress and results to stdout unless quiet
+func Infof(format string, args ...any) {
+	logf(LevelNormal, false, format, args...)
+}
+
+// Verbosef logs a diagnostic to stderr at verbose level and above
+func Verbosef(format string, args ...any) {
+	logf(LevelVerbose, true, format, args...)
+}
+
+// Debugf logs a diagnostic to stderr at debug level
+func Debugf(format string, args ...any) {
+	logf(LevelDebug, true, "debug: "+format, args...)
+}
+
+func logf(l Level, diagnostic bool, format string, args ...any) {
+	mu.Lock()
+	defer mu.Unlock()
+	if level < l {
+		return
+	}
+
+	w := stdout
+	if diagnostic {
+		w = stderr
+	}
+	fmt.Fprintf(w, format, args...)
+	if diagnostic && (len(format) == 0 || format[len(format)-1] != '\n') {
+		fmt.Fprintln(w)
+	}
+}
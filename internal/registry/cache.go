@@ -1,9 +1,16 @@
 package registry
 
 import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -11,28 +18,71 @@ const (
 	DefaultCacheTTL = 1 * time.Hour
 	CacheDir        = ".vibe-skills"
 	CacheFile       = "registry-cache.json"
+
+	// cacheExt marks gzip-compressed entries; uncompressed ".json" entries from
+	// older versions are never read and are evicted by Prune like any other
+	cacheExt = ".json.gz"
+
+	// CacheSchemaVersion identifies the shape of CacheEntry and RegistryIndex.
+	// Bump it whenever either changes so older entries are treated as misses.
+	CacheSchemaVersion = 2
+
+	// DefaultCacheMaxEntries is how many refs are cached before the least recently used are evicted
+	DefaultCacheMaxEntries = 20
 )
 
 // CacheEntry represents a cached registry entry
 type CacheEntry struct {
-	Data      *RegistryIndex `json:"data"`
-	Ref       string         `json:"ref"`
-	FetchedAt time.Time      `json:"fetched_at"`
+	SchemaVersion int            `json:"schema_version"`
+	Data          *RegistryIndex `json:"data"`
+	Ref           string         `json:"ref"`
+	FetchedAt     time.Time      `json:"fetched_at"`
+	ETag          string         `json:"etag,omitempty"`          // Validator for If-None-Match
+	LastModified  string         `json:"last_modified,omitempty"` // Validator for If-Modified-Since
 }
 
 // Cache handles local caching of registry data
 type Cache struct {
-	dir string
-	ttl time.Duration
+	dir        string
+	ttl        time.Duration
+	maxEntries int
 }
 
 // NewCache creates a new cache instance
 func NewCache() *Cache {
+	return NewCacheWithTTL(DefaultCacheTTL)
+}
+
+// NewCacheWithTTL creates a cache whose entries expire after ttl. A zero ttl
+// makes every entry stale, so the registry is revalidated on each run.
+func NewCacheWithTTL(ttl time.Duration) *Cache {
+	if ttl < 0 {
+		ttl = 0
+	}
 	homeDir, _ := os.UserHomeDir()
 	return &Cache{
-		dir: filepath.Join(homeDir, CacheDir, "cache"),
-		ttl: DefaultCacheTTL,
+		dir:        filepath.Join(homeDir, CacheDir, "cache"),
+		ttl:        ttl,
+		maxEntries: DefaultCacheMaxEntries,
+	}
+}
+
+// SetMaxEntries limits how many refs are kept in the cache. Zero disables the limit.
+func (c *Cache) SetMaxEntries(n int) {
+	if n < 0 {
+		n = 0
 	}
+	c.maxEntries = n
+}
+
+// Dir returns the directory cache entries are stored in
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// TTL returns how long cached entries stay fresh
+func (c *Cache) TTL() time.Duration {
+	return c.ttl
 }
 
 // Get retrieves cached registry data if valid
@@ -47,46 +97,223 @@ func (c *Cache) Get(ref string) (*RegistryIndex, bool) {
 		return nil, false
 	}
 
+	c.markUsed(ref)
 	return entry.Data, true
 }
 
+// GetStale retrieves cached registry data even if it has expired, along with
+// when it was fetched
+func (c *Cache) GetStale(ref string) (*RegistryIndex, time.Time, bool) {
+	entry, err := c.loadEntry(ref)
+	if err != nil || entry.Data == nil {
+		return nil, time.Time{}, false
+	}
+	c.markUsed(ref)
+	return entry.Data, entry.FetchedAt, true
+}
+
+// Lookup returns the cached entry for ref regardless of its age, so its
+// validators can be used for a conditional fetch
+func (c *Cache) Lookup(ref string) (*CacheEntry, bool) {
+	entry, err := c.loadEntry(ref)
+	if err != nil || entry.Data == nil {
+		return nil, false
+	}
+	return entry, true
+}
+
 // Set stores registry data in cache
 func (c *Cache) Set(ref string, data *RegistryIndex) error {
+	return c.SetWithValidators(ref, data, "", "")
+}
+
+// SetWithValidators stores registry data together with the ETag and
+// Last-Modified headers it was served with, dropping the ref's cached skill files
+func (c *Cache) SetWithValidators(ref string, data *RegistryIndex, etag, lastModified string) error {
 	entry := &CacheEntry{
-		Data:      data,
-		Ref:       ref,
-		FetchedAt: time.Now(),
+		SchemaVersion: CacheSchemaVersion,
+		Data:          data,
+		Ref:           ref,
+		FetchedAt:     time.Now(),
+		ETag:          etag,
+		LastModified:  lastModified,
+	}
+
+	unlock, err := c.lockRef(ref)
+	if err != nil {
+		return err
+	}
+	// Skill files fetched alongside an older index may be out of date too
+	_ = c.clearFiles(ref)
+	err = c.saveEntry(ref, entry)
+	unlock()
+	if err != nil {
+		return err
+	}
+	return c.Prune()
+}
+
+// Touch marks the cached entry for ref as freshly fetched, after the server
+// confirmed it is unchanged
+func (c *Cache) Touch(ref string) error {
+	unlock, err := c.lockRef(ref)
+	if err != nil {
+		return err
 	}
+	defer unlock()
 
+	entry, err := c.loadEntry(ref)
+	if err != nil {
+		return err
+	}
+	entry.FetchedAt = time.Now()
 	return c.saveEntry(ref, entry)
 }
 
-// Clear removes all cached data
+// Prune evicts the least recently used entries until at most maxEntries remain.
+// Use is tracked through each entry file's modification time.
+func (c *Cache) Prune() error {
+	if c.maxEntries == 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	type cacheFile struct {
+		path   string
+		usedAt time.Time
+	}
+	var files []cacheFile
+	for _, entry := range entries {
+		if entry.IsDir() || !(strings.HasSuffix(entry.Name(), cacheExt) || strings.HasSuffix(entry.Name(), ".json")) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, cacheFile{path: filepath.Join(c.dir, entry.Name()), usedAt: info.ModTime()})
+	}
+	if len(files) <= c.maxEntries {
+		return nil
+	}
+
+	// Most recently used first
+	sort.Slice(files, func(a, b int) bool { return files[a].usedAt.After(files[b].usedAt) })
+	for _, f := range files[c.maxEntries:] {
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		_ = os.RemoveAll(contentDirOf(f.path))
+	}
+	return nil
+}
+
+// markUsed records a cache hit for LRU eviction (best-effort)
+func (c *Cache) markUsed(ref string) {
+	now := time.Now()
+	_ = os.Chtimes(c.getCachePath(ref), now, now)
+}
+
+// Clear removes all cached data, each entry under its lock. The lock files
+// themselves stay, see lockRef.
 func (c *Cache) Clear() error {
-	return os.RemoveAll(c.dir)
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var errs []error
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !(strings.HasSuffix(name, cacheExt) || strings.HasSuffix(name, ".json")) {
+			continue
+		}
+		if err := c.removeEntry(filepath.Join(c.dir, name)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// Then skill files left without an index entry. Lock files stay, and temp
+	// files belong to writers that remove them.
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(c.dir, entry.Name())); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
 }
 
-// ClearRef removes cached data for a specific ref
+// ClearRef removes cached data for a specific ref, returning an error
+// satisfying os.IsNotExist when nothing is cached for it
 func (c *Cache) ClearRef(ref string) error {
 	path := c.getCachePath(ref)
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	return c.removeEntry(path)
+}
+
+// removeEntry removes the index entry at path and its skill files, holding
+// the entry's lock
+func (c *Cache) removeEntry(path string) error {
+	unlock, err := lockEntry(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := os.RemoveAll(contentDirOf(path)); err != nil {
+		return err
+	}
 	return os.Remove(path)
 }
 
 func (c *Cache) getCachePath(ref string) string {
 	// Sanitize ref for filename
 	safeRef := sanitizeFilename(ref)
-	return filepath.Join(c.dir, safeRef+".json")
+	return filepath.Join(c.dir, safeRef+cacheExt)
 }
 
+// loadEntry reads the entry for ref, rejecting entries written with another schema
 func (c *Cache) loadEntry(ref string) (*CacheEntry, error) {
-	path := c.getCachePath(ref)
-	data, err := os.ReadFile(path)
+	entry, err := loadEntryFile(c.getCachePath(ref))
+	if err != nil {
+		return nil, err
+	}
+	if entry.SchemaVersion != CacheSchemaVersion {
+		return nil, fmt.Errorf("cache entry for %s has schema version %d, want %d", ref, entry.SchemaVersion, CacheSchemaVersion)
+	}
+	return entry, nil
+}
+
+func loadEntryFile(path string) (*CacheEntry, error) {
+	f, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
+	defer func() { _ = f.Close() }()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = gz.Close() }()
 
 	var entry CacheEntry
-	if err := json.Unmarshal(data, &entry); err != nil {
+	if err := json.NewDecoder(gz).Decode(&entry); err != nil {
 		return nil, err
 	}
 
@@ -99,24 +326,59 @@ func (c *Cache) saveEntry(ref string, entry *CacheEntry) error {
 		return err
 	}
 
-	data, err := json.MarshalIndent(entry, "", "  ")
+	// Write to a temp file and rename so concurrent readers never see a partial entry
+	return writeGzipJSON(c.dir, c.getCachePath(ref), entry)
+}
+
+// lockRef takes an exclusive advisory lock serializing writers of ref's cache
+// entry across processes, and returns a function releasing it. Lock files are
+// never removed, since a process still holding the lock on a removed file
+// would not exclude one locking its replacement.
+func (c *Cache) lockRef(ref string) (func(), error) {
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return nil, err
+	}
+	return lockEntry(c.getCachePath(ref))
+}
+
+// lockEntry locks the index entry file at path through its .lock sibling
+func lockEntry(path string) (func(), error) {
+	f, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0644)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if err := lockFile(f); err != nil {
+		_ = f.Close()
+		return nil, err
 	}
 
-	path := c.getCachePath(ref)
-	return os.WriteFile(path, data, 0644)
+	return func() {
+		_ = unlockFile(f)
+		_ = f.Close()
+	}, nil
 }
 
+// sanitizeFilename maps a ref to a filename that is safe on every platform and
+// unique per ref: a readable ASCII slug followed by a hash of the exact ref, so
+// refs such as "feature/x" and "feature\x" no longer share a cache file and
+// reserved Windows names like "con" never stand alone. Dots are replaced too,
+// since Windows also reserves those names with any extension ("nul.txt").
 func sanitizeFilename(s string) string {
-	// Replace / with _ for safe filenames
-	result := make([]byte, len(s))
-	for i, c := range s {
-		if c == '/' || c == '\\' || c == ':' {
-			result[i] = '_'
-		} else {
-			result[i] = byte(c)
+	const maxSlug = 64
+
+	var slug strings.Builder
+	for _, c := range s {
+		if slug.Len() >= maxSlug {
+			break
+		}
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-':
+			slug.WriteRune(c)
+		default:
+			slug.WriteByte('_')
 		}
 	}
-	return string(result)
+
+	sum := sha256.Sum256([]byte(s))
+	return slug.String() + "-" + hex.EncodeToString(sum[:6])
 }
@@ -0,0 +1,15 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !windows
+
+package registry
+
+import "os"
+
+// lockFile is a no-op on platforms without advisory locks; cache writes still
+// go through an atomic rename
+func lockFile(f *os.File) error {
+	return nil
+}
+
+func unlockFile(f *os.File) error {
+	return nil
+}
@@ -0,0 +1,97 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CacheStats summarizes the contents of the cache directory
+type CacheStats struct {
+	Dir       string
+	Entries   int   // Number of cache entry files, including unreadable ones
+	TotalSize int64 // Bytes used by entry files and cached skill files
+	Details   []CacheEntryStats
+}
+
+// CacheEntryStats describes a single cached ref
+type CacheEntryStats struct {
+	File      string // Base name of the entry file
+	Ref       string // Empty if the entry could not be read
+	Size      int64  // Bytes used by the entry and its cached skill files
+	FetchedAt time.Time
+	Age       time.Duration
+	Valid     bool // Current schema and younger than the cache TTL
+	Skills    int  // Number of skills in the cached index
+	Files     int  // Number of skills whose files are cached
+}
+
+// Stats reports the number, size, and age of cached entries, sorted by ref.
+// A missing cache directory yields empty stats.
+func (c *Cache) Stats() (CacheStats, error) {
+	stats := CacheStats{Dir: c.dir}
+
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return stats, nil
+		}
+		return stats, err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !(strings.HasSuffix(entry.Name(), cacheExt) || strings.HasSuffix(entry.Name(), ".json")) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		detail := CacheEntryStats{File: entry.Name(), Size: info.Size()}
+		if strings.HasSuffix(entry.Name(), cacheExt) {
+			if cached, err := loadEntryFile(filepath.Join(c.dir, entry.Name())); err == nil && cached.Data != nil {
+				detail.Ref = cached.Ref
+				detail.FetchedAt = cached.FetchedAt
+				detail.Age = time.Since(cached.FetchedAt)
+				detail.Valid = cached.SchemaVersion == CacheSchemaVersion && detail.Age <= c.ttl
+				detail.Skills = len(cached.Data.Skills)
+			}
+		}
+
+		detail.Files, detail.Size = contentStats(contentDirOf(filepath.Join(c.dir, entry.Name())), detail.Size)
+
+		stats.Entries++
+		stats.TotalSize += detail.Size
+		stats.Details = append(stats.Details, detail)
+	}
+
+	sort.Slice(stats.Details, func(a, b int) bool {
+		if stats.Details[a].Ref != stats.Details[b].Ref {
+			return stats.Details[a].Ref < stats.Details[b].Ref
+		}
+		return stats.Details[a].File < stats.Details[b].File
+	})
+	return stats, nil
+}
+
+// contentStats counts the cached skills in a skill files directory and adds
+// their size to size
+func contentStats(dir string, size int64) (int, int64) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, size
+	}
+	skills := 0
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !strings.HasSuffix(entry.Name(), cacheExt) {
+			continue
+		}
+		skills++
+		size += info.Size()
+	}
+	return skills, size
+}
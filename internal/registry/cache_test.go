@@ -0,0 +1,161 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSanitizeFilenameASCII(t *testing.T) {
+	for _, ref := range []string{"功能/x", "café", "🚀", "ブランチ", "naïve\\ref"} {
+		t.Run(ref, func(t *testing.T) {
+			got := sanitizeFilename(ref)
+			for _, c := range got {
+				if c > 0x7f {
+					t.Fatalf("sanitizeFilename(%q) = %q, contains non-ASCII %q", ref, got, c)
+				}
+				if strings.ContainsRune(`/\:*?"<>|`, c) {
+					t.Fatalf("sanitizeFilename(%q) = %q, contains separator or reserved %q", ref, got, c)
+				}
+			}
+		})
+	}
+}
+
+func TestSanitizeFilenameUnique(t *testing.T) {
+	pairs := [][2]string{
+		{"feature/x", `feature\x`},
+		{"feature/x", "feature_x"},
+		{"功能", "功能功能"},
+		{"café", "cafe"},
+	}
+	for _, pair := range pairs {
+		if a, b := sanitizeFilename(pair[0]), sanitizeFilename(pair[1]); a == b {
+			t.Errorf("sanitizeFilename(%q) and sanitizeFilename(%q) are both %q", pair[0], pair[1], a)
+		}
+	}
+}
+
+func TestSanitizeFilenameReservedWindowsNames(t *testing.T) {
+	reserved := map[string]bool{"CON": true, "PRN": true, "AUX": true, "NUL": true, "COM1": true, "LPT1": true}
+
+	for _, ref := range []string{"con", "CON", "nul.txt", "aux", "com1", "LPT1.log"} {
+		t.Run(ref, func(t *testing.T) {
+			got := sanitizeFilename(ref)
+			base, _, _ := strings.Cut(got, ".")
+			if reserved[strings.ToUpper(base)] {
+				t.Errorf("sanitizeFilename(%q) = %q, a reserved Windows name", ref, got)
+			}
+		})
+	}
+}
+
+func TestSanitizeFilenameLength(t *testing.T) {
+	long := strings.Repeat("a", 1000)
+	got := sanitizeFilename(long)
+	if len(got) > 100 {
+		t.Errorf("sanitizeFilename of a %d byte ref is %d bytes, want at most 100", len(long), len(got))
+	}
+	if got == sanitizeFilename(long+"b") {
+		t.Error("long refs differing after the slug limit share a filename")
+	}
+
+	multibyte := strings.Repeat("功", 500)
+	if got := sanitizeFilename(multibyte); len(got) > 100 {
+		t.Errorf("sanitizeFilename of a multibyte ref is %d bytes, want at most 100", len(got))
+	}
+}
+
+func TestSanitizeFilenameDeterministic(t *testing.T) {
+	for _, ref := range []string{"main", "v1.2.3", "功能/x", "feature\\x", "con"} {
+		if a, b := sanitizeFilename(ref), sanitizeFilename(ref); a != b {
+			t.Errorf("sanitizeFilename(%q) gave %q then %q", ref, a, b)
+		}
+	}
+}
+
+// newTestCache returns a cache in its own temp directory
+func newTestCache(t *testing.T) *Cache {
+	t.Helper()
+	c := NewCacheWithTTL(time.Hour)
+	c.dir = filepath.Join(t.TempDir(), "cache")
+	return c
+}
+
+func TestCacheClearKeepsLockFiles(t *testing.T) {
+	c := newTestCache(t)
+	for _, ref := range []string{"main", "develop", "org/repo@main"} {
+		if err := c.Set(ref, &RegistryIndex{Version: "1"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.SetFiles(ref, "skills/a/SKILL.md", map[string][]byte{"SKILL.md": []byte("a")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := c.Clear(); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	locks := 0
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".lock") {
+			t.Errorf("Clear left %s", entry.Name())
+			continue
+		}
+		locks++
+	}
+	if locks != 3 {
+		t.Errorf("Clear left %d lock files, want all 3 kept", locks)
+	}
+	if _, ok := c.Get("main"); ok {
+		t.Error("entry still readable after Clear")
+	}
+}
+
+func TestCacheClearRef(t *testing.T) {
+	c := newTestCache(t)
+
+	if err := c.ClearRef("main"); !os.IsNotExist(err) {
+		t.Fatalf("ClearRef of an empty cache = %v, want a not-exist error", err)
+	}
+	if _, err := os.Stat(c.dir); !os.IsNotExist(err) {
+		t.Error("ClearRef of an uncached ref created the cache directory")
+	}
+
+	if err := c.Set("main", &RegistryIndex{Version: "1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Set("develop", &RegistryIndex{Version: "1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ClearRef("main"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Get("main"); ok {
+		t.Error("main still cached after ClearRef")
+	}
+	if _, ok := c.Get("develop"); !ok {
+		t.Error("ClearRef removed another ref")
+	}
+	if _, err := os.Stat(c.getCachePath("main") + ".lock"); err != nil {
+		t.Errorf("ClearRef removed the lock file: %v", err)
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	public := NewGitHubRegistry(&GitHubRegistryOptions{})
+	if got := public.CacheKey("develop"); got != "develop" {
+		t.Errorf("public registry CacheKey = %q, want the bare ref", got)
+	}
+	private := NewGitHubRegistry(&GitHubRegistryOptions{Owner: "acme", Repo: "skills"})
+	if got := private.CacheKey("develop"); got != "acme/skills@develop" {
+		t.Errorf("private registry CacheKey = %q, want acme/skills@develop", got)
+	}
+}
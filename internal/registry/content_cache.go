@@ -0,0 +1,129 @@
+package registry
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// contentEntry is the cached content of one skill's files at a ref
+type contentEntry struct {
+	SchemaVersion int               `json:"schema_version"`
+	Ref           string            `json:"ref"`
+	Skill         string            `json:"skill"` // Path of the skill's SKILL.md
+	FetchedAt     time.Time         `json:"fetched_at"`
+	Files         map[string][]byte `json:"files"` // Relative path -> content
+}
+
+// GetFiles returns the cached files of the skill whose SKILL.md is at
+// skillPath, provided every one of paths is cached and, unless anyAge is set,
+// they were fetched within the cache TTL
+func (c *Cache) GetFiles(ref, skillPath string, paths []string, anyAge bool) (map[string][]byte, bool) {
+	entry, err := loadContentFile(c.contentPath(ref, skillPath))
+	if err != nil || entry.SchemaVersion != CacheSchemaVersion || entry.Skill != skillPath {
+		return nil, false
+	}
+	if !anyAge && time.Since(entry.FetchedAt) > c.ttl {
+		return nil, false
+	}
+
+	files := make(map[string][]byte, len(paths))
+	for _, path := range paths {
+		content, ok := entry.Files[path]
+		if !ok {
+			return nil, false
+		}
+		files[path] = content
+	}
+	return files, true
+}
+
+// SetFiles stores the files of the skill whose SKILL.md is at skillPath
+func (c *Cache) SetFiles(ref, skillPath string, files map[string][]byte) error {
+	entry := &contentEntry{
+		SchemaVersion: CacheSchemaVersion,
+		Ref:           ref,
+		Skill:         skillPath,
+		FetchedAt:     time.Now(),
+		Files:         files,
+	}
+	dir := c.contentDir(ref)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return writeGzipJSON(dir, c.contentPath(ref, skillPath), entry)
+}
+
+// clearFiles removes the cached skill files of ref
+func (c *Cache) clearFiles(ref string) error {
+	return os.RemoveAll(c.contentDir(ref))
+}
+
+// contentDir is the directory holding the cached skill files of ref, next to
+// its index entry
+func (c *Cache) contentDir(ref string) string {
+	return contentDirOf(c.getCachePath(ref))
+}
+
+// contentDirOf returns the skill files directory belonging to an index entry file
+func contentDirOf(entryPath string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(entryPath, cacheExt), ".json") + ".files"
+}
+
+func (c *Cache) contentPath(ref, skillPath string) string {
+	return filepath.Join(c.contentDir(ref), sanitizeFilename(skillPath)+cacheExt)
+}
+
+func loadContentFile(path string) (*contentEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = gz.Close() }()
+
+	var entry contentEntry
+	if err := json.NewDecoder(gz).Decode(&entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// writeGzipJSON writes v to path as gzip-compressed JSON, through a temp file
+// in dir so concurrent readers never see a partial file
+func writeGzipJSON(dir, path string, v any) error {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(gz).Encode(v); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
@@ -0,0 +1,12 @@
+package registry
+
+import "errors"
+
+var (
+	// ErrSkillNotFound is returned by Find when no skill has the requested name
+	ErrSkillNotFound = errors.New("skill not found")
+
+	// ErrNetwork wraps failures to reach a registry at all, as opposed to
+	// error responses such as a 404
+	ErrNetwork = errors.New("network error")
+)
@@ -0,0 +1,275 @@
+package registry
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/cuongtl1992/vibe-skills/internal/logging"
+)
+
+const (
+	// GitStack is the stack reported for skills fetched from a Git repository
+	GitStack = "git"
+
+	githubAPIURL = "https://api.github.com"
+	githubHost   = "github.com/"
+)
+
+// GitSource identifies a skill directory in a GitHub repository, written as
+// github.com/org/repo//path/to/skill@ref
+type GitSource struct {
+	Owner string
+	Repo  string
+	Path  string // Skill directory within the repository, empty for the root
+	Ref   string // Branch, tag, or commit; empty for the default branch
+}
+
+func (s GitSource) String() string {
+	str := githubHost + s.Owner + "/" + s.Repo
+	if s.Path != "" {
+		str += "//" + s.Path
+	}
+	if s.Ref != "" {
+		str += "@" + s.Ref
+	}
+	return str
+}
+
+// IsGitURL reports whether arg refers to a skill in a GitHub repository
+func IsGitURL(arg string) bool {
+	return strings.HasPrefix(trimScheme(arg), githubHost)
+}
+
+func trimScheme(s string) string {
+	s = strings.TrimPrefix(s, "https://")
+	return strings.TrimPrefix(s, "http://")
+}
+
+// ParseGitSource parses a github.com/org/repo//path@ref reference. Both the
+// path and the ref are optional.
+func ParseGitSource(s string) (GitSource, error) {
+	rest := strings.TrimPrefix(trimScheme(s), githubHost)
+	if rest == trimScheme(s) {
+		return GitSource{}, fmt.Errorf("unsupported git source %q: only github.com repositories are supported", s)
+	}
+
+	var src GitSource
+	if at := strings.LastIndex(rest, "@"); at >= 0 {
+		src.Ref = rest[at+1:]
+		rest = rest[:at]
+	}
+	if sep := strings.Index(rest, "//"); sep >= 0 {
+		src.Path = strings.Trim(rest[sep+2:], "/")
+		rest = rest[:sep]
+	}
+
+	parts := strings.Split(strings.TrimSuffix(strings.Trim(rest, "/"), ".git"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return GitSource{}, fmt.Errorf("invalid git source %q: expected github.com/org/repo//path@ref", s)
+	}
+	src.Owner, src.Repo = parts[0], parts[1]
+
+	if src.Path != "" && (path.Clean(src.Path) != src.Path || strings.HasPrefix(src.Path, "..")) {
+		return GitSource{}, fmt.Errorf("invalid path in git source %q", s)
+	}
+
+	return src, nil
+}
+
+// GitProvider serves a single skill fetched from a GitHub repository, so teams
+// can install skills kept in their own (possibly private) repos. Set one of
+// GitTokenEnvVars to access private repositories.
+type GitProvider struct {
+	source GitSource
+	skill  Skill
+	files  map[string]SkillFile
+}
+
+// NewGitProvider downloads the repository archive for source and reads the
+// skill directory from it
+func NewGitProvider(source string) (*GitProvider, error) {
+	src, err := ParseGitSource(source)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Timeout: 60 * time.Second}
+	files, err := fetchGitSubtree(client, githubAPIURL, src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", src, err)
+	}
+	if _, ok := files["SKILL.md"]; !ok {
+		return nil, fmt.Errorf("no SKILL.md found in %s", src)
+	}
+
+	name := src.Repo
+	if src.Path != "" {
+		name = path.Base(src.Path)
+	}
+
+	return &GitProvider{
+		source: src,
+		skill:  skillFromFiles(GitStack, name, files),
+		files:  files,
+	}, nil
+}
+
+// fetchGitSubtree downloads the repository tarball and returns the files under src.Path
+func fetchGitSubtree(client *http.Client, apiURL string, src GitSource) (map[string]SkillFile, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/tarball", apiURL, src.Owner, src.Repo)
+	if src.Ref != "" {
+		url += "/" + src.Ref
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if token := GitTokenFromEnv(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	logging.Verbosef("GET %s", url)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNetwork, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("repository or ref not found (set GITHUB_TOKEN for private repositories)")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, url)
+	}
+
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	prefix := ""
+	if src.Path != "" {
+		prefix = src.Path + "/"
+	}
+
+	files := make(map[string]SkillFile)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		// Entries are nested under a single "<owner>-<repo>-<sha>/" directory
+		name := header.Name
+		if slash := strings.Index(name, "/"); slash >= 0 {
+			name = name[slash+1:]
+		}
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		rel := strings.TrimPrefix(name, prefix)
+		if rel == "" || hasHiddenElement(rel) || path.Clean(rel) != rel || strings.HasPrefix(rel, "../") {
+			continue
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", rel, err)
+		}
+		files[rel] = SkillFile{Content: data, Mode: os.FileMode(header.Mode).Perm()}
+	}
+
+	return files, nil
+}
+
+// hasHiddenElement reports whether any element of a slash-separated path starts with a dot
+func hasHiddenElement(rel string) bool {
+	for _, elem := range strings.Split(rel, "/") {
+		if strings.HasPrefix(elem, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Source returns the parsed repository reference
+func (p *GitProvider) Source() GitSource {
+	return p.source
+}
+
+// Skill returns the fetched skill
+func (p *GitProvider) Skill() Skill {
+	return p.skill
+}
+
+// List returns the fetched skill
+func (p *GitProvider) List() ([]Skill, error) {
+	return []Skill{p.skill}, nil
+}
+
+// ListByStack returns the fetched skill if stack is GitStack
+func (p *GitProvider) ListByStack(stack string) ([]Skill, error) {
+	if stack != GitStack {
+		return nil, nil
+	}
+	return p.List()
+}
+
+// ListByTag returns the fetched skill if it carries the tag
+func (p *GitProvider) ListByTag(tag string) ([]Skill, error) {
+	return FilterByTags([]Skill{p.skill}, []string{tag}), nil
+}
+
+// Find returns the fetched skill if name matches it
+func (p *GitProvider) Find(name string) (*Skill, error) {
+	if name == p.skill.Name || name == GitStack+"/"+p.skill.Name {
+		skill := p.skill
+		return &skill, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrSkillNotFound, name)
+}
+
+// GetContent returns the content of the skill's SKILL.md
+func (p *GitProvider) GetContent(skill *Skill) ([]byte, error) {
+	return p.files["SKILL.md"].Content, nil
+}
+
+// GetFiles returns every file in the skill directory
+// Returns map of relative path -> content
+func (p *GitProvider) GetFiles(skill *Skill) (map[string][]byte, error) {
+	result := make(map[string][]byte, len(p.files))
+	for path, file := range p.files {
+		result[path] = file.Content
+	}
+	return result, nil
+}
+
+// GetSkillFiles returns every file in the skill directory with its mode
+func (p *GitProvider) GetSkillFiles(skill *Skill) (map[string]SkillFile, error) {
+	result := make(map[string]SkillFile, len(p.files))
+	for path, file := range p.files {
+		result[path] = SkillFile{Content: file.Content, Mode: FileMode(&p.skill, path, file.Content)}
+	}
+	return result, nil
+}
+
+// GetRef returns the source reference, recorded in the lockfile in place of a registry ref
+func (p *GitProvider) GetRef() string {
+	return p.source.String()
+}
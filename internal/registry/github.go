@@ -1,12 +1,20 @@
 package registry
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"os"
+	"reflect"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/cuongtl1992/vibe-skills/internal/logging"
 )
 
 const (
@@ -14,8 +22,31 @@ const (
 	DefaultRepo   = "vibe-skills"
 	DefaultBranch = "main"
 	RawGitHubURL  = "https://raw.githubusercontent.com"
+
+	// maxFetchParallel bounds the concurrent requests made for one skill's files
+	maxFetchParallel = 8
 )
 
+// RegistryTokenEnv holds the token for a private registry. The generic GitHub
+// variables are not used for the registry, since CI often sets them to tokens
+// scoped to other repositories.
+const RegistryTokenEnv = "VIBE_SKILLS_TOKEN"
+
+// GitTokenEnvVars are the environment variables checked, in order, for a
+// GitHub token used to read private repositories given as Git sources. They
+// are never sent to the registry, see RegistryTokenEnv.
+var GitTokenEnvVars = []string{"GITHUB_TOKEN", "GH_TOKEN"}
+
+// GitTokenFromEnv returns the first GitHub token set in GitTokenEnvVars
+func GitTokenFromEnv() string {
+	for _, name := range GitTokenEnvVars {
+		if token := os.Getenv(name); token != "" {
+			return token
+		}
+	}
+	return ""
+}
+
 // GitHubRegistry fetches skills from GitHub
 type GitHubRegistry struct {
 	owner   string
@@ -23,7 +54,24 @@ type GitHubRegistry struct {
 	ref     string // branch, tag, or commit
 	cache   *Cache
 	noCache bool
+	offline bool
+	onStale func(fetchedAt time.Time, err error)
 	client  *http.Client
+	token   string
+	apiURL  string
+
+	// resolveOnce expands an abbreviated commit SHA in ref before first use;
+	// refMu guards ref, which fetches running in parallel read meanwhile
+	resolveOnce sync.Once
+	resolveErr  error
+	refMu       sync.RWMutex
+
+	// sources are the base URLs content is fetched from, GitHub first and then
+	// the mirrors; source indexes the one that last answered
+	sources  []string
+	sourceMu sync.Mutex
+	source   int
+	onMirror func(mirror string, err error)
 }
 
 // GitHubRegistryOptions configures the GitHub registry
@@ -33,6 +81,22 @@ type GitHubRegistryOptions struct {
 	Branch  string
 	Ref     string // Takes precedence over Branch if set
 	NoCache bool   // Skip cache and fetch fresh from registry
+	Cache   *Cache // Defaults to NewCache()
+	Offline bool   // Serve the cached index, however old, without contacting GitHub
+	Token   string // Sent to GitHub, not to mirrors, to read private registries
+
+	// OnStale is called when an expired cached index is served because GitHub
+	// was unreachable (err is the network error) or Offline is set (err is nil)
+	OnStale func(fetchedAt time.Time, err error)
+
+	// Mirrors are base URLs tried in order when GitHub cannot be reached. Each
+	// serves the layout of raw.githubusercontent.com/<owner>/<repo>, that is
+	// <mirror>/<ref>/skills/registry.json.
+	Mirrors []string
+
+	// OnMirror is called when a mirror answers after the sources before it
+	// failed with a network error (err is the first such error)
+	OnMirror func(mirror string, err error)
 }
 
 // NewGitHubRegistry creates a new GitHub-based registry
@@ -58,16 +122,37 @@ func NewGitHubRegistry(opts *GitHubRegistryOptions) *GitHubRegistry {
 	if ref == "" {
 		ref = DefaultBranch
 	}
+	if IsCommitSHA(ref) {
+		ref = strings.ToLower(ref)
+	}
+
+	cache := opts.Cache
+	if cache == nil {
+		cache = NewCache()
+	}
+
+	sources := []string{fmt.Sprintf("%s/%s/%s", RawGitHubURL, owner, repo)}
+	for _, mirror := range opts.Mirrors {
+		if mirror = strings.TrimRight(strings.TrimSpace(mirror), "/"); mirror != "" {
+			sources = append(sources, mirror)
+		}
+	}
 
 	return &GitHubRegistry{
 		owner:   owner,
 		repo:    repo,
 		ref:     ref,
-		cache:   NewCache(),
+		cache:   cache,
 		noCache: opts.NoCache,
+		offline: opts.Offline,
+		onStale: opts.OnStale,
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		token:    opts.Token,
+		apiURL:   githubAPIURL,
+		sources:  sources,
+		onMirror: opts.OnMirror,
 	}
 }
 
@@ -96,6 +181,15 @@ func (g *GitHubRegistry) ListByStack(stack string) ([]Skill, error) {
 	return result, nil
 }
 
+// ListByTag returns skills carrying the tag
+func (g *GitHubRegistry) ListByTag(tag string) ([]Skill, error) {
+	skills, err := g.List()
+	if err != nil {
+		return nil, err
+	}
+	return FilterByTags(skills, []string{tag}), nil
+}
+
 // GetStacks returns all available stack names
 func (g *GitHubRegistry) GetStacks() ([]string, error) {
 	skills, err := g.List()
@@ -132,129 +226,436 @@ func (g *GitHubRegistry) Find(name string) (*Skill, error) {
 			return &s, nil
 		}
 	}
-	return nil, fmt.Errorf("skill not found: %s", name)
+	return nil, fmt.Errorf("%w: %s", ErrSkillNotFound, name)
 }
 
-// Search returns skills matching the query
+// Search returns skills matching the query, most relevant first
 func (g *GitHubRegistry) Search(query string) ([]Skill, error) {
 	skills, err := g.List()
 	if err != nil {
 		return nil, err
 	}
-
-	query = strings.ToLower(query)
-	var result []Skill
-	for _, s := range skills {
-		if strings.Contains(strings.ToLower(s.Name), query) ||
-			strings.Contains(strings.ToLower(s.Description), query) ||
-			strings.Contains(strings.ToLower(s.Stack), query) {
-			result = append(result, s)
-		}
-	}
-	return result, nil
+	return SearchSkills(skills, query), nil
 }
 
-// GetContent returns the content of a skill's SKILL.md
+// GetContent returns the content of a skill's SKILL.md, retrying transient failures
 func (g *GitHubRegistry) GetContent(skill *Skill) ([]byte, error) {
-	url := g.buildRawURL("skills/" + skill.Path)
-	return g.fetch(url)
+	return g.fetchWithRetry("skills/" + skill.Path)
 }
 
-// GetFiles returns all files for a multi-file skill
+// GetFiles returns all files for a multi-file skill, fetching up to
+// maxFetchParallel of them at once and retrying each on transient failures.
+// Fetched files are cached like the index: for the cache TTL, without expiry
+// for a pinned commit, and at any age when offline or when GitHub cannot be
+// reached.
 // Returns map of relative path -> content
 func (g *GitHubRegistry) GetFiles(skill *Skill) (map[string][]byte, error) {
-	files := make(map[string][]byte)
-
-	// Always fetch main SKILL.md
-	content, err := g.GetContent(skill)
-	if err != nil {
+	if err := g.resolveRef(); err != nil {
 		return nil, err
 	}
-	files["SKILL.md"] = content
-
-	// Fetch additional files if present
-	if len(skill.Files) == 0 {
-		return files, nil
-	}
 
 	// Get skill directory from path (e.g., "dotnet/clean-architecture" from "dotnet/clean-architecture/SKILL.md")
 	skillDir := strings.TrimSuffix(skill.Path, "/SKILL.md")
 
+	// Always fetch main SKILL.md, first so its error is reported first
+	paths := []string{"SKILL.md"}
 	for _, filePath := range skill.Files {
-		if filePath == "SKILL.md" {
-			continue // Already fetched
+		if filePath != "SKILL.md" {
+			paths = append(paths, filePath)
 		}
+	}
 
-		// Build URL: skills/{stack}/{folder}/{filePath}
-		url := g.buildRawURL("skills/" + skillDir + "/" + filePath)
-		data, err := g.fetch(url)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch %s: %w", filePath, err)
+	if !g.noCache {
+		// Skill files at a commit never change, so any cached copy is current
+		anyAge := g.offline || IsFullCommitSHA(g.GetRef())
+		if files, ok := g.cache.GetFiles(g.cacheKey(), skill.Path, paths, anyAge); ok {
+			logging.Verbosef("skill %s: using cached files", skill.Name)
+			return files, nil
 		}
-		files[filePath] = data
 	}
 
+	contents := make([][]byte, len(paths))
+	errs := make([]error, len(paths))
+	sem := make(chan struct{}, maxFetchParallel)
+	var wg sync.WaitGroup
+
+	for idx, filePath := range paths {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(idx int, filePath string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if filePath == "SKILL.md" {
+				contents[idx], errs[idx] = g.GetContent(skill)
+				return
+			}
+			// Build path: skills/{stack}/{folder}/{filePath}
+			contents[idx], errs[idx] = g.fetchWithRetry("skills/" + skillDir + "/" + filePath)
+			if errs[idx] != nil {
+				errs[idx] = fmt.Errorf("failed to fetch %s: %w", filePath, errs[idx])
+			}
+		}(idx, filePath)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		// Fall back to a stale copy when GitHub cannot be reached at all
+		var netErr net.Error
+		if !g.noCache && errors.As(err, &netErr) {
+			if files, ok := g.cache.GetFiles(g.cacheKey(), skill.Path, paths, true); ok {
+				logging.Verbosef("skill %s: registry unreachable, using cached files", skill.Name)
+				return files, nil
+			}
+		}
+		return nil, err
+	}
+
+	files := make(map[string][]byte, len(paths))
+	for idx, filePath := range paths {
+		files[filePath] = contents[idx]
+	}
+
+	if !g.noCache {
+		// Best-effort, ignore error
+		//nolint:errcheck
+		g.cache.SetFiles(g.cacheKey(), skill.Path, files)
+	}
 	return files, nil
 }
 
+// GetSkillFiles returns all files for a skill with their file modes. Files listed
+// in the skill's executables, or starting with a shebang, are marked executable.
+func (g *GitHubRegistry) GetSkillFiles(skill *Skill) (map[string]SkillFile, error) {
+	files, err := g.GetFiles(skill)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]SkillFile, len(files))
+	for path, content := range files {
+		result[path] = SkillFile{Content: content, Mode: FileMode(skill, path, content)}
+	}
+	return result, nil
+}
+
+// FileMode returns the mode a skill file should be installed with
+func FileMode(skill *Skill, path string, content []byte) os.FileMode {
+	for _, exe := range skill.Executables {
+		if exe == path {
+			return 0755
+		}
+	}
+	if bytes.HasPrefix(content, []byte("#!")) {
+		return 0755
+	}
+	return 0644
+}
+
 // fetchIndex fetches and caches the registry index
 func (g *GitHubRegistry) fetchIndex() (*RegistryIndex, error) {
+	if err := g.resolveRef(); err != nil {
+		return nil, err
+	}
+
+	// The index at a commit never changes, so any cached copy is current
+	pinned := IsFullCommitSHA(g.GetRef())
+
+	if g.offline {
+		index, fetchedAt, ok := g.cache.GetStale(g.cacheKey())
+		if !ok {
+			return nil, fmt.Errorf("no cached registry for %s: run once without --offline", g.GetRef())
+		}
+		if g.onStale != nil && !pinned && time.Since(fetchedAt) > g.cache.TTL() {
+			g.onStale(fetchedAt, nil)
+		}
+		return index, nil
+	}
+
 	// Try cache first (unless --no-cache flag is set)
+	var cached *CacheEntry
 	if !g.noCache {
-		if cached, ok := g.cache.Get(g.ref); ok {
-			return cached, nil
+		if pinned {
+			if index, _, ok := g.cache.GetStale(g.cacheKey()); ok {
+				logging.Verbosef("registry %s: using cached index of pinned commit", g.cacheKey())
+				return index, nil
+			}
 		}
+		if index, ok := g.cache.Get(g.cacheKey()); ok {
+			logging.Verbosef("registry %s: using cached index", g.cacheKey())
+			return index, nil
+		}
+		// An expired entry can still be revalidated instead of downloaded again
+		cached, _ = g.cache.Lookup(g.cacheKey())
 	}
 
-	// Fetch from GitHub
-	url := g.buildRawURL("skills/registry.json")
-	data, err := g.fetch(url)
+	// Fetch from GitHub, or a mirror if GitHub is unreachable
+	var etag, lastModified string
+	if cached != nil {
+		etag, lastModified = cached.ETag, cached.LastModified
+	}
+	result, err := g.fetchFromSources("skills/registry.json", etag, lastModified)
 	if err != nil {
+		// Fall back to the stale copy when GitHub cannot be reached at all
+		var netErr net.Error
+		if !g.noCache && errors.As(err, &netErr) {
+			if index, fetchedAt, ok := g.cache.GetStale(g.cacheKey()); ok {
+				if g.onStale != nil {
+					g.onStale(fetchedAt, err)
+				}
+				return index, nil
+			}
+		}
 		return nil, fmt.Errorf("failed to fetch registry: %w", err)
 	}
 
+	if result.NotModified {
+		logging.Verbosef("registry %s: index not modified, reusing cached copy", g.cacheKey())
+		// Best-effort, ignore error
+		//nolint:errcheck
+		g.cache.Touch(g.cacheKey())
+		return cached.Data, nil
+	}
+
 	var index RegistryIndex
-	if err := json.Unmarshal(data, &index); err != nil {
+	if err := json.Unmarshal(result.Data, &index); err != nil {
 		return nil, fmt.Errorf("failed to parse registry: %w", err)
 	}
 
 	// Cache the result (best-effort, ignore error)
 	//nolint:errcheck
-	g.cache.Set(g.ref, &index)
+	g.cache.SetWithValidators(g.cacheKey(), &index, result.ETag, result.LastModified)
 
 	return &index, nil
 }
 
-// buildRawURL builds a raw GitHub content URL
-func (g *GitHubRegistry) buildRawURL(path string) string {
-	return fmt.Sprintf("%s/%s/%s/%s/%s", RawGitHubURL, g.owner, g.repo, g.ref, path)
+// cacheKey names the cached index: the ref for the default registry, and
+// owner/repo@ref for any other repository so their indexes do not collide
+func (g *GitHubRegistry) cacheKey() string {
+	return g.CacheKey(g.GetRef())
+}
+
+// CacheKey returns the key the index of ref in this registry's repository is
+// cached under, as listed by the cache stats
+func (g *GitHubRegistry) CacheKey(ref string) string {
+	if g.isDefaultRepo() {
+		return ref
+	}
+	return fmt.Sprintf("%s/%s@%s", g.owner, g.repo, ref)
 }
 
-// fetch performs an HTTP GET request
-func (g *GitHubRegistry) fetch(url string) ([]byte, error) {
-	resp, err := g.client.Get(url)
+// isDefaultRepo reports whether the registry is the public vibe-skills repository
+func (g *GitHubRegistry) isDefaultRepo() bool {
+	return g.owner == DefaultOwner && g.repo == DefaultRepo
+}
+
+// buildRawURL builds a content URL for path under the given source
+func (g *GitHubRegistry) buildRawURL(source, path string) string {
+	return fmt.Sprintf("%s/%s/%s", source, g.GetRef(), path)
+}
+
+// fetch downloads a file of the registry repository
+func (g *GitHubRegistry) fetch(path string) ([]byte, error) {
+	if err := g.resolveRef(); err != nil {
+		return nil, err
+	}
+	result, err := g.fetchFromSources(path, "", "")
+	if err != nil {
+		return nil, err
+	}
+	return result.Data, nil
+}
+
+// fetchFromSources fetches path from the source that last answered, moving on
+// to the following mirrors while requests fail with a network error. Other
+// failures, such as a 404, are returned as they are. When every source is
+// unreachable the first network error is returned.
+func (g *GitHubRegistry) fetchFromSources(path, etag, lastModified string) (*fetchResult, error) {
+	g.sourceMu.Lock()
+	start := g.source
+	g.sourceMu.Unlock()
+
+	var firstErr error
+	for idx := start; idx < len(g.sources); idx++ {
+		// Only GitHub gets the token; mirrors are separate hosts
+		token := ""
+		if idx == 0 {
+			token = g.token
+		}
+
+		result, err := g.fetchIfModified(g.buildRawURL(g.sources[idx], path), token, etag, lastModified)
+		var netErr net.Error
+		if err != nil && errors.As(err, &netErr) {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		if err == nil && idx != start {
+			g.useSource(idx, firstErr)
+		}
+		return result, err
+	}
+	return nil, firstErr
+}
+
+// useSource makes later requests go straight to the source at idx
+func (g *GitHubRegistry) useSource(idx int, cause error) {
+	g.sourceMu.Lock()
+	switched := idx > g.source
+	if switched {
+		g.source = idx
+	}
+	g.sourceMu.Unlock()
+
+	if switched && g.onMirror != nil {
+		g.onMirror(g.sources[idx], cause)
+	}
+}
+
+// fetchResult is the outcome of a conditional GET
+type fetchResult struct {
+	Data         []byte
+	ETag         string
+	LastModified string
+	NotModified  bool // The server answered 304; Data is empty
+}
+
+// fetchIfModified performs an HTTP GET, sending If-None-Match and
+// If-Modified-Since when validators from a previous response are given, and
+// the token, if any, as a bearer token
+func (g *GitHubRegistry) fetchIfModified(url, token, etag, lastModified string) (*fetchResult, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	logging.Verbosef("GET %s", url)
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNetwork, err)
+	}
 	defer func() { _ = resp.Body.Close() }()
+	logging.Debugf("%s: %s", url, resp.Status)
+
+	if resp.StatusCode == http.StatusNotModified && (etag != "" || lastModified != "") {
+		return &fetchResult{NotModified: true, ETag: etag, LastModified: lastModified}, nil
+	}
 
 	if resp.StatusCode == http.StatusNotFound {
+		if token == "" && !g.isDefaultRepo() {
+			return nil, fmt.Errorf("not found: %s (set %s for private registries)", url, RegistryTokenEnv)
+		}
 		return nil, fmt.Errorf("not found: %s", url)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, url)
+		return nil, &StatusError{StatusCode: resp.StatusCode, URL: url}
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
 	}
 
-	return io.ReadAll(resp.Body)
+	return &fetchResult{
+		Data:         data,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}, nil
 }
 
-// GetRef returns the current ref (branch/tag)
+// GetRef returns the current ref (branch, tag, or commit), with an abbreviated
+// commit SHA expanded once the index has been fetched
 func (g *GitHubRegistry) GetRef() string {
+	g.refMu.RLock()
+	defer g.refMu.RUnlock()
 	return g.ref
 }
 
+// Warm makes sure the cache holds a current index for the ref, fetching it
+// unless a fresh copy is already cached, and reports whether one was
+func (g *GitHubRegistry) Warm() (cached bool, err error) {
+	if g.offline {
+		return false, fmt.Errorf("cannot warm the cache while offline")
+	}
+	if err := g.resolveRef(); err != nil {
+		return false, err
+	}
+
+	if !g.noCache {
+		if _, ok := g.cache.Get(g.cacheKey()); ok {
+			return true, nil
+		}
+		if _, _, ok := g.cache.GetStale(g.cacheKey()); ok && IsFullCommitSHA(g.GetRef()) {
+			return true, nil
+		}
+	}
+
+	// Revalidating an expired entry or downloading it anew both store the result
+	if _, err := g.fetchIndex(); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+// Refresh revalidates the cached index with the registry whatever its age,
+// using its ETag so an unchanged index is not downloaded again, and reports
+// whether the index differs from the cached copy
+func (g *GitHubRegistry) Refresh() (index *RegistryIndex, changed bool, err error) {
+	if g.offline {
+		return nil, false, fmt.Errorf("cannot refresh the registry while offline")
+	}
+	if err := g.resolveRef(); err != nil {
+		return nil, false, err
+	}
+
+	// The index at a commit never changes
+	if IsFullCommitSHA(g.GetRef()) {
+		index, err := g.fetchIndex()
+		return index, false, err
+	}
+
+	var etag, lastModified string
+	cached, ok := g.cache.Lookup(g.cacheKey())
+	if ok && !g.noCache {
+		etag, lastModified = cached.ETag, cached.LastModified
+	}
+	result, err := g.fetchFromSources("skills/registry.json", etag, lastModified)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to fetch registry: %w", err)
+	}
+
+	if result.NotModified {
+		logging.Verbosef("registry %s: index not modified", g.cacheKey())
+		// Best-effort, ignore error
+		//nolint:errcheck
+		g.cache.Touch(g.cacheKey())
+		return cached.Data, false, nil
+	}
+
+	index = &RegistryIndex{}
+	if err := json.Unmarshal(result.Data, index); err != nil {
+		return nil, false, fmt.Errorf("failed to parse registry: %w", err)
+	}
+
+	// Cache the result (best-effort, ignore error)
+	//nolint:errcheck
+	g.cache.SetWithValidators(g.cacheKey(), index, result.ETag, result.LastModified)
+
+	return index, !ok || !reflect.DeepEqual(cached.Data, index), nil
+}
+
 // ClearCache clears the registry cache
 func (g *GitHubRegistry) ClearCache() error {
-	return g.cache.ClearRef(g.ref)
+	return g.cache.ClearRef(g.cacheKey())
 }
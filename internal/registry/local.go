@@ -0,0 +1,243 @@
+package registry
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LocalStack is the stack reported for skills read from a local directory
+const LocalStack = "local"
+
+// LocalProvider serves a single skill from a directory on disk, letting skill
+// authors install work in progress without publishing it to the registry
+type LocalProvider struct {
+	dir   string
+	skill Skill
+}
+
+// localFrontmatter holds the SKILL.md frontmatter fields used to describe a skill outside the registry
+type localFrontmatter struct {
+	Name         string   `yaml:"name"`
+	Description  string   `yaml:"description"`
+	Dependencies []string `yaml:"dependencies"`
+	Tags         []string `yaml:"tags"`
+}
+
+// IsLocalPath reports whether arg refers to a local directory rather than a registry skill name
+func IsLocalPath(arg string) bool {
+	if arg == "." || arg == ".." || filepath.IsAbs(arg) {
+		return true
+	}
+	for _, prefix := range []string{"./", "../", "~/", `.\`, `..\`} {
+		if strings.HasPrefix(arg, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewLocalProvider reads the skill in dir, which must contain a SKILL.md. The
+// skill is named by its frontmatter, falling back to the directory name.
+func NewLocalProvider(dir string) (*LocalProvider, error) {
+	if strings.HasPrefix(dir, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get home directory: %w", err)
+		}
+		dir = filepath.Join(homeDir, dir[2:])
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", dir, err)
+	}
+
+	if _, err := os.Stat(filepath.Join(absDir, "SKILL.md")); err != nil {
+		return nil, fmt.Errorf("failed to read SKILL.md in %s: %w", dir, err)
+	}
+
+	p := &LocalProvider{dir: absDir}
+	files, err := p.walk()
+	if err != nil {
+		return nil, err
+	}
+	p.skill = skillFromFiles(LocalStack, filepath.Base(absDir), files)
+
+	return p, nil
+}
+
+// skillFromFiles describes a skill outside the registry from its files. The
+// skill is named by its frontmatter, falling back to defaultName.
+func skillFromFiles(stack, defaultName string, files map[string]SkillFile) Skill {
+	skill := Skill{
+		Name:  defaultName,
+		Stack: stack,
+		Path:  "SKILL.md",
+	}
+
+	// Frontmatter problems are left for install-time validation to report
+	var fm localFrontmatter
+	if data, ok := localFrontmatterBlock(files["SKILL.md"].Content); ok && yaml.Unmarshal(data, &fm) == nil {
+		if fm.Name != "" {
+			skill.Name = fm.Name
+		}
+		skill.Description = fm.Description
+		skill.Dependencies = fm.Dependencies
+		skill.Tags = fm.Tags
+	}
+
+	for _, rel := range sortedKeys(files) {
+		if rel != "SKILL.md" {
+			skill.Files = append(skill.Files, rel)
+		}
+		if files[rel].Mode&0111 != 0 {
+			skill.Executables = append(skill.Executables, rel)
+		}
+	}
+	return skill
+}
+
+// localFrontmatterBlock returns the YAML between the leading "---" delimiters, if any
+func localFrontmatterBlock(content []byte) ([]byte, bool) {
+	content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+	if !bytes.HasPrefix(content, []byte("---\n")) {
+		return nil, false
+	}
+	rest := content[len("---\n"):]
+	end := bytes.Index(rest, []byte("\n---"))
+	if end < 0 {
+		return nil, false
+	}
+	return rest[:end], true
+}
+
+// Dir returns the absolute directory the skill is read from
+func (p *LocalProvider) Dir() string {
+	return p.dir
+}
+
+// Skill returns the local skill
+func (p *LocalProvider) Skill() Skill {
+	return p.skill
+}
+
+// List returns the local skill
+func (p *LocalProvider) List() ([]Skill, error) {
+	return []Skill{p.skill}, nil
+}
+
+// ListByStack returns the local skill if stack is LocalStack
+func (p *LocalProvider) ListByStack(stack string) ([]Skill, error) {
+	if stack != LocalStack {
+		return nil, nil
+	}
+	return p.List()
+}
+
+// ListByTag returns the local skill if it carries the tag
+func (p *LocalProvider) ListByTag(tag string) ([]Skill, error) {
+	return FilterByTags([]Skill{p.skill}, []string{tag}), nil
+}
+
+// Find returns the local skill if name matches it
+func (p *LocalProvider) Find(name string) (*Skill, error) {
+	if name == p.skill.Name || name == LocalStack+"/"+p.skill.Name {
+		skill := p.skill
+		return &skill, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrSkillNotFound, name)
+}
+
+// GetContent returns the content of the skill's SKILL.md
+func (p *LocalProvider) GetContent(skill *Skill) ([]byte, error) {
+	return os.ReadFile(filepath.Join(p.dir, "SKILL.md"))
+}
+
+// GetFiles returns every file in the skill directory
+// Returns map of relative path -> content
+func (p *LocalProvider) GetFiles(skill *Skill) (map[string][]byte, error) {
+	files, err := p.walk()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]byte, len(files))
+	for path, file := range files {
+		result[path] = file.Content
+	}
+	return result, nil
+}
+
+// GetSkillFiles returns every file in the skill directory with its mode. Files
+// that are executable on disk, or start with a shebang, are marked executable.
+func (p *LocalProvider) GetSkillFiles(skill *Skill) (map[string]SkillFile, error) {
+	files, err := p.walk()
+	if err != nil {
+		return nil, err
+	}
+
+	for path, file := range files {
+		file.Mode = FileMode(&p.skill, path, file.Content)
+		files[path] = file
+	}
+	return files, nil
+}
+
+// GetRef identifies the source directory in place of a git ref
+func (p *LocalProvider) GetRef() string {
+	return "local:" + p.dir
+}
+
+// walk reads the skill directory, skipping hidden files and directories such as .git
+func (p *LocalProvider) walk() (map[string]SkillFile, error) {
+	files := make(map[string]SkillFile)
+	err := filepath.WalkDir(p.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != p.dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(p.dir, path)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = SkillFile{Content: data, Mode: info.Mode().Perm()}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read skill directory: %w", err)
+	}
+	return files, nil
+}
+
+func sortedKeys(files map[string]SkillFile) []string {
+	keys := make([]string, 0, len(files))
+	for key := range files {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,117 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/cuongtl1992/vibe-skills/internal/logging"
+)
+
+// PingReport describes how the registry index for a ref can be reached
+type PingReport struct {
+	Repo     string // owner/repo
+	Ref      string
+	Cached   bool      // The cache holds an index for the ref
+	CachedAt time.Time // When the cached index was fetched
+	Fresh    bool      // The cached index is within the cache TTL
+	Sources  []PingResult
+}
+
+// PingResult is the outcome of requesting the index from one source
+type PingResult struct {
+	URL         string
+	Mirror      bool
+	Status      int // HTTP status, 0 if there was no response
+	Latency     time.Duration
+	NotModified bool  // The source confirmed the cached index is current
+	Skills      int   // Skills in the index, if it parsed
+	Err         error // Network error, unexpected status or unparsable index
+}
+
+// Ping requests the index from GitHub and each mirror in turn, revalidating
+// the cached copy if there is one, without using or updating the cache
+func (g *GitHubRegistry) Ping() (*PingReport, error) {
+	if g.offline {
+		return nil, fmt.Errorf("cannot ping the registry while offline")
+	}
+	if err := g.resolveRef(); err != nil {
+		return nil, err
+	}
+
+	report := &PingReport{Repo: g.owner + "/" + g.repo, Ref: g.GetRef()}
+	cached, ok := g.cache.Lookup(g.cacheKey())
+	if ok {
+		report.Cached = true
+		report.CachedAt = cached.FetchedAt
+		report.Fresh = time.Since(cached.FetchedAt) <= g.cache.TTL()
+	}
+
+	for idx, source := range g.sources {
+		// Only GitHub gets the token; mirrors are separate hosts
+		token := ""
+		if idx == 0 {
+			token = g.token
+		}
+		result := g.ping(g.buildRawURL(source, "skills/registry.json"), token, cached)
+		result.Mirror = idx > 0
+		report.Sources = append(report.Sources, result)
+	}
+	return report, nil
+}
+
+// ping times a conditional GET of the index at url and checks the answer
+func (g *GitHubRegistry) ping(url, token string, cached *CacheEntry) PingResult {
+	result := PingResult{URL: url}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if cached != nil && cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+	if cached != nil && cached.LastModified != "" {
+		req.Header.Set("If-Modified-Since", cached.LastModified)
+	}
+	logging.Verbosef("GET %s", url)
+
+	start := time.Now()
+	resp, err := g.client.Do(req)
+	if err != nil {
+		result.Latency = time.Since(start)
+		result.Err = fmt.Errorf("%w: %w", ErrNetwork, err)
+		return result
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(resp.Body)
+	result.Latency = time.Since(start)
+	result.Status = resp.StatusCode
+	logging.Debugf("%s: %s in %s", url, resp.Status, result.Latency)
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		result.NotModified = true
+		if cached.Data != nil {
+			result.Skills = len(cached.Data.Skills)
+		}
+	case resp.StatusCode != http.StatusOK:
+		result.Err = fmt.Errorf("HTTP %d", resp.StatusCode)
+	case err != nil:
+		result.Err = fmt.Errorf("%w: %w", ErrNetwork, err)
+	default:
+		var index RegistryIndex
+		if err := json.Unmarshal(data, &index); err != nil {
+			result.Err = fmt.Errorf("index does not parse: %w", err)
+			return result
+		}
+		result.Skills = len(index.Skills)
+	}
+	return result
+}
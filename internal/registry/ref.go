@@ -0,0 +1,158 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/cuongtl1992/vibe-skills/internal/logging"
+)
+
+const (
+	// MinCommitSHALength is the shortest abbreviated commit SHA accepted as a ref
+	MinCommitSHALength = 7
+
+	fullCommitSHALength = 40
+)
+
+// IsCommitSHA reports whether ref is a full or abbreviated commit SHA, that is
+// 7 to 40 hexadecimal digits. A branch or tag spelled that way is treated as
+// a commit.
+func IsCommitSHA(ref string) bool {
+	if len(ref) < MinCommitSHALength || len(ref) > fullCommitSHALength {
+		return false
+	}
+	for _, c := range ref {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsFullCommitSHA reports whether ref is a full 40 digit commit SHA. The
+// registry at such a ref never changes, so its cached index never expires.
+func IsFullCommitSHA(ref string) bool {
+	return len(ref) == fullCommitSHALength && IsCommitSHA(ref)
+}
+
+// ValidateRef checks that ref can name a branch, tag or commit, following
+// the rules of git check-ref-format
+func ValidateRef(ref string) error {
+	switch {
+	case ref == "":
+		return fmt.Errorf("invalid ref: empty")
+	case strings.HasPrefix(ref, "-"), strings.HasPrefix(ref, "/"), strings.HasSuffix(ref, "/"),
+		strings.HasSuffix(ref, "."), strings.HasSuffix(ref, ".lock"):
+		return fmt.Errorf("invalid ref %q", ref)
+	case strings.Contains(ref, ".."), strings.Contains(ref, "//"), strings.Contains(ref, "@{"):
+		return fmt.Errorf("invalid ref %q", ref)
+	}
+	for _, c := range ref {
+		if c <= ' ' || c == 0x7f || strings.ContainsRune("~^:?*[\\", c) {
+			return fmt.Errorf("invalid ref %q: contains %q", ref, c)
+		}
+	}
+	return nil
+}
+
+// resolveRef expands an abbreviated commit SHA to the full SHA, once, so the
+// cache and lockfile record the exact commit. Branches and tags are left as
+// they are. When the GitHub API cannot be reached, offline or behind a
+// mirror, a cached index for a matching full SHA is used instead, and failing
+// that the abbreviated SHA is kept.
+func (g *GitHubRegistry) resolveRef() error {
+	g.resolveOnce.Do(func() {
+		ref := g.GetRef()
+		if !IsCommitSHA(ref) || IsFullCommitSHA(ref) {
+			return
+		}
+
+		var full string
+		if !g.offline {
+			full, g.resolveErr = g.lookupCommit(ref)
+			var netErr net.Error
+			if g.resolveErr != nil && !errors.As(g.resolveErr, &netErr) {
+				return
+			}
+			g.resolveErr = nil
+		}
+		if full == "" {
+			full = g.cachedCommit(ref)
+		}
+		if full != "" {
+			logging.Debugf("ref %s resolved to commit %s", ref, full)
+			g.refMu.Lock()
+			g.ref = full
+			g.refMu.Unlock()
+		}
+	})
+	return g.resolveErr
+}
+
+// lookupCommit asks the GitHub API for the full SHA of an abbreviated one
+func (g *GitHubRegistry) lookupCommit(sha string) (string, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/commits/%s", g.apiURL, g.owner, g.repo, sha)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github.sha")
+	if g.token != "" {
+		req.Header.Set("Authorization", "Bearer "+g.token)
+	}
+	logging.Verbosef("GET %s", url)
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrNetwork, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	logging.Debugf("%s: %s", url, resp.Status)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusUnprocessableEntity:
+		return "", fmt.Errorf("no commit %s in %s/%s", sha, g.owner, g.repo)
+	default:
+		// Rate limits and the like should not stop a fetch that may still work
+		logging.Verbosef("cannot resolve commit %s: HTTP %d", sha, resp.StatusCode)
+		return "", nil
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	full := strings.TrimSpace(string(data))
+	if !IsFullCommitSHA(full) || !strings.HasPrefix(full, strings.ToLower(sha)) {
+		return "", fmt.Errorf("unexpected commit SHA %q for %s", full, sha)
+	}
+	return full, nil
+}
+
+// cachedCommit returns the full SHA of the single cached index whose commit
+// starts with sha, or "" if there is none or more than one
+func (g *GitHubRegistry) cachedCommit(sha string) string {
+	stats, err := g.cache.Stats()
+	if err != nil {
+		return ""
+	}
+
+	prefix := strings.TrimSuffix(g.cacheKey(), g.GetRef())
+	var match string
+	for _, entry := range stats.Details {
+		ref, ok := strings.CutPrefix(entry.Ref, prefix)
+		if !ok || !IsFullCommitSHA(ref) || !strings.HasPrefix(ref, strings.ToLower(sha)) {
+			continue
+		}
+		if match != "" && match != ref {
+			return ""
+		}
+		match = ref
+	}
+	return match
+}
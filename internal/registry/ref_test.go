@@ -0,0 +1,54 @@
+package registry
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestResolveRefConcurrent(t *testing.T) {
+	const full = "abc1234def5678abc1234def5678abc1234def56"
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/commits/abc1234") {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, full)
+	}))
+	defer api.Close()
+
+	g := NewGitHubRegistry(&GitHubRegistryOptions{Owner: "acme", Repo: "skills", Ref: "abc1234", Cache: newTestCache(t)})
+	g.apiURL = api.URL
+
+	var wg sync.WaitGroup
+	for n := 0; n < 8; n++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if err := g.resolveRef(); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for k := 0; k < 100; k++ {
+				if ref := g.GetRef(); ref != "abc1234" && ref != full {
+					t.Errorf("GetRef() = %q during resolution", ref)
+					return
+				}
+				_ = g.cacheKey()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := g.GetRef(); got != full {
+		t.Errorf("GetRef() = %q after resolution, want %q", got, full)
+	}
+	if got := g.cacheKey(); got != "acme/skills@"+full {
+		t.Errorf("cacheKey() = %q, want the full SHA", got)
+	}
+}
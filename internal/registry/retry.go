@@ -0,0 +1,54 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cuongtl1992/vibe-skills/internal/logging"
+)
+
+const (
+	// fileFetchAttempts bounds how often a skill file is requested when the
+	// registry fails transiently
+	fileFetchAttempts = 3
+)
+
+// fileRetryDelay is the wait before the first retry, doubling after each one
+var fileRetryDelay = 500 * time.Millisecond
+
+// StatusError is an unexpected HTTP status from the registry
+type StatusError struct {
+	StatusCode int
+	URL        string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.URL)
+}
+
+// isTransient reports whether a failed request may succeed if repeated: the
+// registry could not be reached at all, or answered with a server error
+func isTransient(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= http.StatusInternalServerError || statusErr.StatusCode == http.StatusTooManyRequests
+	}
+	return errors.Is(err, ErrNetwork)
+}
+
+// fetchWithRetry fetches a file of the registry repository, retrying
+// transient failures with exponential backoff
+func (g *GitHubRegistry) fetchWithRetry(path string) ([]byte, error) {
+	delay := fileRetryDelay
+	for attempt := 1; ; attempt++ {
+		data, err := g.fetch(path)
+		if err == nil || attempt >= fileFetchAttempts || !isTransient(err) {
+			return data, err
+		}
+		logging.Verbosef("%s: attempt %d/%d failed (%v), retrying in %s", path, attempt, fileFetchAttempts, err, delay)
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
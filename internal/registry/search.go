@@ -0,0 +1,145 @@
+package registry
+
+import (
+	"sort"
+	"strings"
+)
+
+// SearchSkills ranks skills against a query. Every whitespace-separated term
+// must match the skill's name, a tag, the description or stack, either as a
+// substring, a near miss of a name word (one typo), or a subsequence of the
+// name. Results are ordered by relevance, then by name.
+func SearchSkills(skills []Skill, query string) []Skill {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return nil
+	}
+
+	type scored struct {
+		skill Skill
+		score int
+	}
+	var matches []scored
+
+	for _, skill := range skills {
+		total := 0
+		for _, term := range terms {
+			score := scoreTerm(skill, term)
+			if score == 0 {
+				total = 0
+				break
+			}
+			total += score
+		}
+		if total > 0 {
+			matches = append(matches, scored{skill: skill, score: total})
+		}
+	}
+
+	sort.SliceStable(matches, func(a, b int) bool {
+		if matches[a].score != matches[b].score {
+			return matches[a].score > matches[b].score
+		}
+		return matches[a].skill.Name < matches[b].skill.Name
+	})
+
+	result := make([]Skill, 0, len(matches))
+	for _, m := range matches {
+		result = append(result, m.skill)
+	}
+	return result
+}
+
+// scoreTerm returns how well a single lowercase term matches a skill, 0 for no match
+func scoreTerm(skill Skill, term string) int {
+	name := strings.ToLower(skill.Name)
+	words := strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' || r == '.' })
+
+	switch {
+	case name == term:
+		return 100
+	case strings.HasPrefix(name, term):
+		return 80
+	case containsWord(words, term):
+		return 70
+	case strings.Contains(name, term):
+		return 60
+	}
+
+	if len(term) >= 4 {
+		for _, word := range words {
+			if withinOneEdit(word, term) {
+				return 45
+			}
+		}
+	}
+
+	switch {
+	case skill.HasTag(term):
+		return 50
+	case strings.Contains(strings.ToLower(skill.Description), term):
+		return 40
+	case strings.ToLower(skill.Stack) == term:
+		return 30
+	case strings.Contains(strings.ToLower(skill.Stack), term):
+		return 20
+	case isSubsequence(term, name):
+		return 10
+	}
+	return 0
+}
+
+func containsWord(words []string, term string) bool {
+	for _, word := range words {
+		if word == term {
+			return true
+		}
+	}
+	return false
+}
+
+// withinOneEdit reports whether a and b differ by at most one insertion,
+// deletion or substitution
+func withinOneEdit(a, b string) bool {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) > len(rb) {
+		ra, rb = rb, ra
+	}
+	if len(rb)-len(ra) > 1 {
+		return false
+	}
+
+	i, j, edits := 0, 0, 0
+	for i < len(ra) && j < len(rb) {
+		if ra[i] == rb[j] {
+			i++
+			j++
+			continue
+		}
+		edits++
+		if edits > 1 {
+			return false
+		}
+		if len(ra) == len(rb) {
+			i++
+		}
+		j++
+	}
+	return edits+(len(rb)-j)+(len(ra)-i) <= 1
+}
+
+// isSubsequence reports whether the runes of sub appear in s in order
+func isSubsequence(sub, s string) bool {
+	rs := []rune(s)
+	j := 0
+	for _, r := range sub {
+		for j < len(rs) && rs[j] != r {
+			j++
+		}
+		if j == len(rs) {
+			return false
+		}
+		j++
+	}
+	return true
+}
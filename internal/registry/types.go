@@ -1,12 +1,52 @@
 package registry
 
+import (
+	"os"
+	"strings"
+	"time"
+)
+
 // Skill represents a skill in the registry
 type Skill struct {
-	Name        string   `json:"name"`
-	Stack       string   `json:"stack"`
-	Description string   `json:"description"`
-	Path        string   `json:"path"`
-	Files       []string `json:"files,omitempty"` // Additional files for multi-file skills
+	Name         string     `json:"name"`
+	Stack        string     `json:"stack"`
+	Description  string     `json:"description"`
+	Path         string     `json:"path"`
+	Files        []string   `json:"files,omitempty"`        // Additional files for multi-file skills
+	Executables  []string   `json:"executables,omitempty"`  // Files that must be installed executable
+	Dependencies []string   `json:"dependencies,omitempty"` // Skills that must be installed alongside this one
+	Tags         []string   `json:"tags,omitempty"`         // Keywords for discovery across stacks
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`   // When the skill last changed, if the registry records it
+}
+
+// HasTag reports whether the skill carries tag, ignoring case
+func (s Skill) HasTag(tag string) bool {
+	for _, t := range s.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterByTags returns the skills carrying any of tags
+func FilterByTags(skills []Skill, tags []string) []Skill {
+	var result []Skill
+	for _, s := range skills {
+		for _, tag := range tags {
+			if s.HasTag(tag) {
+				result = append(result, s)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// SkillFile is a skill file's content together with the mode it should be written with
+type SkillFile struct {
+	Content []byte
+	Mode    os.FileMode
 }
 
 // RegistryIndex represents the registry.json structure
@@ -23,13 +63,16 @@ type Registry interface {
 	// ListByStack returns skills filtered by stack
 	ListByStack(stack string) ([]Skill, error)
 
+	// ListByTag returns skills carrying the tag
+	ListByTag(tag string) ([]Skill, error)
+
 	// GetStacks returns all available stack names
 	GetStacks() ([]string, error)
 
 	// Find returns a skill by name (supports both "skill-name" and "stack/skill-name")
 	Find(name string) (*Skill, error)
 
-	// Search returns skills matching the query
+	// Search returns skills matching the query, most relevant first
 	Search(query string) ([]Skill, error)
 
 	// GetContent returns the content of a skill's SKILL.md
@@ -38,4 +81,7 @@ type Registry interface {
 	// GetFiles returns all files for a multi-file skill
 	// Returns map of relative path -> content
 	GetFiles(skill *Skill) (map[string][]byte, error)
+
+	// GetSkillFiles returns all files for a skill along with their file modes
+	GetSkillFiles(skill *Skill) (map[string]SkillFile, error)
 }
@@ -0,0 +1,97 @@
+package updater
+
+import (
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"fmt"
+	"runtime"
+)
+
+// validateExecutable checks that the file at path is an executable for the
+// running platform, catching mismatched assets or downloaded error pages
+func validateExecutable(path string) error {
+	return validateExecutableFor(path, runtime.GOOS, runtime.GOARCH)
+}
+
+func validateExecutableFor(path, goos, goarch string) error {
+	switch goos {
+	case "windows":
+		return validatePE(path, goarch)
+	case "darwin":
+		return validateMachO(path, goarch)
+	default:
+		return validateELF(path, goarch)
+	}
+}
+
+func validateELF(path, goarch string) error {
+	f, err := elf.Open(path)
+	if err != nil {
+		return fmt.Errorf("not a valid ELF executable: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	want := map[string]elf.Machine{
+		"amd64": elf.EM_X86_64,
+		"arm64": elf.EM_AARCH64,
+		"386":   elf.EM_386,
+		"arm":   elf.EM_ARM,
+	}
+	if m, ok := want[goarch]; ok && f.Machine != m {
+		return fmt.Errorf("binary architecture %s does not match %s", f.Machine, goarch)
+	}
+	return nil
+}
+
+func validateMachO(path, goarch string) error {
+	want := map[string]macho.Cpu{
+		"amd64": macho.CpuAmd64,
+		"arm64": macho.CpuArm64,
+		"386":   macho.Cpu386,
+	}
+
+	// Universal binaries bundle several architectures
+	if fat, err := macho.OpenFat(path); err == nil {
+		defer func() { _ = fat.Close() }()
+		cpu, ok := want[goarch]
+		if !ok {
+			return nil
+		}
+		for _, arch := range fat.Arches {
+			if arch.Cpu == cpu {
+				return nil
+			}
+		}
+		return fmt.Errorf("universal binary does not contain %s", goarch)
+	}
+
+	f, err := macho.Open(path)
+	if err != nil {
+		return fmt.Errorf("not a valid Mach-O executable: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if cpu, ok := want[goarch]; ok && f.Cpu != cpu {
+		return fmt.Errorf("binary architecture %s does not match %s", f.Cpu, goarch)
+	}
+	return nil
+}
+
+func validatePE(path, goarch string) error {
+	f, err := pe.Open(path)
+	if err != nil {
+		return fmt.Errorf("not a valid PE executable: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	want := map[string]uint16{
+		"amd64": pe.IMAGE_FILE_MACHINE_AMD64,
+		"arm64": pe.IMAGE_FILE_MACHINE_ARM64,
+		"386":   pe.IMAGE_FILE_MACHINE_I386,
+	}
+	if m, ok := want[goarch]; ok && f.Machine != m {
+		return fmt.Errorf("binary machine type 0x%x does not match %s", f.Machine, goarch)
+	}
+	return nil
+}
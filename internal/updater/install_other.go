@@ -0,0 +1,17 @@
+//go:build !windows
+
+package updater
+
+import "os"
+
+// installExecutable moves the binary at newPath to execPath, replacing it.
+// Unix systems let a running executable be replaced; the process keeps the
+// old inode open until it exits.
+func installExecutable(newPath, execPath string) error {
+	// Try rename first (faster, same filesystem)
+	if err := os.Rename(newPath, execPath); err != nil {
+		// Fallback to copy if rename fails (cross-device link)
+		return copyFile(newPath, execPath)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+//go:build windows
+
+package updater
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+const moveFileDelayUntilReboot = 0x4
+
+var procMoveFileExW = syscall.NewLazyDLL("kernel32.dll").NewProc("MoveFileExW")
+
+// installExecutable moves the binary at newPath to execPath. Windows refuses
+// to overwrite a running executable but lets it be renamed, so the current
+// one is moved to execPath+".old" first. That file is still locked until the
+// process exits; it is removed by CleanupOldExecutable on the next start,
+// or at reboot if the system allows scheduling it.
+func installExecutable(newPath, execPath string) error {
+	oldPath := execPath + oldSuffix
+	// A leftover from the previous update may still be held by a running process
+	if err := os.Remove(oldPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove %s: %w", oldPath, err)
+	}
+
+	if err := os.Rename(execPath, oldPath); err != nil {
+		return fmt.Errorf("failed to move running executable aside: %w", err)
+	}
+	if err := os.Rename(newPath, execPath); err != nil {
+		if err := copyFile(newPath, execPath); err != nil {
+			_ = os.Rename(oldPath, execPath)
+			return err
+		}
+	}
+
+	scheduleDelete(oldPath)
+	return nil
+}
+
+// scheduleDelete asks Windows to delete path at the next reboot. This needs
+// administrator rights, so failures are ignored.
+func scheduleDelete(path string) {
+	p, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return
+	}
+	_, _, _ = procMoveFileExW.Call(uintptr(unsafe.Pointer(p)), 0, moveFileDelayUntilReboot)
+}
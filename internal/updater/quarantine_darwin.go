@@ -0,0 +1,30 @@
+//go:build darwin
+
+package updater
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+const quarantineAttr = "com.apple.quarantine"
+
+// clearQuarantine removes the quarantine attribute from path, which makes
+// Gatekeeper refuse to run a downloaded binary that is not notarized. A file
+// without the attribute is left alone.
+func clearQuarantine(path string) error {
+	p, err := syscall.BytePtrFromString(path)
+	if err != nil {
+		return err
+	}
+	name, err := syscall.BytePtrFromString(quarantineAttr)
+	if err != nil {
+		return err
+	}
+
+	_, _, errno := syscall.Syscall(syscall.SYS_REMOVEXATTR, uintptr(unsafe.Pointer(p)), uintptr(unsafe.Pointer(name)), 0)
+	if errno != 0 && errno != syscall.ENOATTR {
+		return errno
+	}
+	return nil
+}
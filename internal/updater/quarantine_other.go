@@ -0,0 +1,8 @@
+//go:build !darwin
+
+package updater
+
+// clearQuarantine is a no-op: only macOS quarantines downloaded files
+func clearQuarantine(path string) error {
+	return nil
+}
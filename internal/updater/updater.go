@@ -3,84 +3,290 @@ package updater
 import (
 	"archive/tar"
 	"archive/zip"
-	"bytes"
+	"compress/bzip2"
 	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
+	"runtime/debug"
 	"strings"
+	"time"
 
+	"github.com/cuongtl1992/vibe-skills/internal/logging"
 	"github.com/cuongtl1992/vibe-skills/internal/version"
+	"github.com/ulikunitz/xz"
 )
 
 const (
-	repoOwner = "cuongtl1992"
-	repoName  = "vibe-skills"
+	repoOwner   = "cuongtl1992"
+	repoName    = "vibe-skills"
+	projectName = "vibe-skills"
+	apiBaseURL  = "https://api.github.com"
+
+	checksumsFileName = "checksums.txt"
+	backupSuffix      = ".bak"
+	oldSuffix         = ".old" // Running executable moved aside on Windows
+
+	defaultMaxAttempts = 3
+	initialRetryDelay  = 500 * time.Millisecond
+)
+
+// httpClient performs all updater requests; override with SetHTTPClient
+var httpClient = http.DefaultClient
+
+// SetHTTPClient sets the client used for release lookups and downloads, e.g. to
+// configure a proxy or custom root CAs. Passing nil restores http.DefaultClient.
+func SetHTTPClient(c *http.Client) {
+	if c == nil {
+		c = http.DefaultClient
+	}
+	httpClient = c
+}
+
+// Source identifies the GitHub repository releases are fetched from
+type Source struct {
+	Owner string
+	Repo  string
+	// APIBaseURL overrides https://api.github.com, e.g.
+	// https://github.example.com/api/v3 for GitHub Enterprise
+	APIBaseURL string
+	// ProjectName is the release archive prefix; defaults to Repo
+	ProjectName string
+}
+
+// source is the repository used by all updater requests; override with SetSource
+var source = defaultSource()
+
+func defaultSource() Source {
+	return Source{
+		Owner:       repoOwner,
+		Repo:        repoName,
+		APIBaseURL:  apiBaseURL,
+		ProjectName: projectName,
+	}
+}
+
+// SetSource points the updater at a fork or mirror's releases
+func SetSource(owner, name string) {
+	SetSourceOptions(Source{Owner: owner, Repo: name})
+}
+
+// SetSourceOptions configures the release source; empty fields fall back to the defaults
+func SetSourceOptions(src Source) {
+	def := defaultSource()
+	if src.Owner == "" {
+		src.Owner = def.Owner
+	}
+	if src.Repo == "" {
+		src.Repo = def.Repo
+	}
+	if src.APIBaseURL == "" {
+		src.APIBaseURL = def.APIBaseURL
+	}
+	src.APIBaseURL = strings.TrimSuffix(src.APIBaseURL, "/")
+	if src.ProjectName == "" {
+		src.ProjectName = src.Repo
+	}
+	source = src
+}
+
+// Release channels selectable by CheckForUpdate and UpdateOptions.Channel
+const (
+	ChannelStable  = "stable"
+	ChannelBeta    = "beta"
+	ChannelNightly = "nightly"
 )
 
 type Release struct {
-	TagName string  `json:"tag_name"`
-	Assets  []Asset `json:"assets"`
+	TagName    string  `json:"tag_name"`
+	Prerelease bool    `json:"prerelease"`
+	Draft      bool    `json:"draft"`
+	Assets     []Asset `json:"assets"`
 }
 
 type Asset struct {
 	Name               string `json:"name"`
 	BrowserDownloadURL string `json:"browser_download_url"`
+	Size               int64  `json:"size"` // Bytes, as reported by GitHub; 0 if unknown
+}
+
+// CheckForUpdate reports the newest version available on channel and whether it is
+// newer than the running version. An empty channel means stable.
+func CheckForUpdate(channel string) (string, bool, error) {
+	return CheckForUpdateContext(context.Background(), channel)
 }
 
-func CheckForUpdate() (string, bool, error) {
-	release, err := getLatestRelease()
+// CheckForUpdateContext is CheckForUpdate with cancellation and deadlines taken from ctx
+func CheckForUpdateContext(ctx context.Context, channel string) (string, bool, error) {
+	check, err := CheckForUpdateWithOptions(ctx, &CheckOptions{Channel: channel})
 	if err != nil {
 		return "", false, err
 	}
+	return check.Version, check.HasUpdate, nil
+}
+
+// CheckOptions configures CheckForUpdateWithOptions
+type CheckOptions struct {
+	// Channel selects which releases are considered; defaults to stable
+	Channel string
+	// Prerelease considers every published release, prereleases included,
+	// and picks the highest version regardless of Channel
+	Prerelease bool
+}
+
+// UpdateCheck is the outcome of CheckForUpdateWithOptions
+type UpdateCheck struct {
+	Version    string // Newest version found, or the running one if it is not older
+	HasUpdate  bool   // Version is newer than the running version
+	Prerelease bool   // Version is a prerelease
+}
+
+// CheckForUpdateWithOptions reports the newest release selected by opts and
+// whether it is newer than the running version
+func CheckForUpdateWithOptions(ctx context.Context, opts *CheckOptions) (*UpdateCheck, error) {
+	if opts == nil {
+		opts = &CheckOptions{}
+	}
+
+	var release *Release
+	err := withRetry(ctx, defaultMaxAttempts, func() (err error) {
+		release, err = selectRelease(ctx, opts.Channel, opts.Prerelease)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	currentVersion := version.GetVersion()
-	latestVersion := strings.TrimPrefix(release.TagName, "v")
+	latest := &UpdateCheck{
+		Version:    strings.TrimPrefix(release.TagName, "v"),
+		HasUpdate:  true,
+		Prerelease: release.Prerelease,
+	}
+	current := &UpdateCheck{Version: currentVersion}
 
 	if currentVersion == "dev" {
-		return latestVersion, true, nil
+		return latest, nil
 	}
 
-	if latestVersion != currentVersion {
-		return latestVersion, true, nil
+	cmp, err := version.Compare(latest.Version, currentVersion)
+	if err != nil {
+		// Fall back to plain comparison for non-semver builds
+		if latest.Version != strings.TrimPrefix(currentVersion, "v") {
+			return latest, nil
+		}
+		return current, nil
+	}
+	if cmp > 0 {
+		return latest, nil
 	}
 
-	return currentVersion, false, nil
+	return current, nil
+}
+
+// UpdateOptions configures how SelfUpdateWithOptions installs a release
+type UpdateOptions struct {
+	// VerifySignature requires a detached <asset>.sig signature to validate
+	// against PublicKey before the binary is installed
+	VerifySignature bool
+	// PublicKey is the raw Ed25519 public key used to verify signatures
+	PublicKey []byte
+	// Channel selects which releases are considered; defaults to stable
+	Channel string
+	// Prerelease installs the highest published release, prereleases
+	// included, regardless of Channel
+	Prerelease bool
+	// Progress, if set, is called as the release archive downloads
+	Progress ProgressFunc
+	// MaxAttempts bounds how often transient network failures are retried;
+	// zero uses the default of 3
+	MaxAttempts int
 }
 
+// ProgressFunc receives the bytes downloaded so far and the total size,
+// which is -1 when the server does not report a Content-Length
+type ProgressFunc func(downloaded, total int64)
+
 func SelfUpdate() error {
-	release, err := getLatestRelease()
+	return SelfUpdateWithOptions(nil)
+}
+
+// SelfUpdateWithOptions downloads and installs the latest release using opts
+func SelfUpdateWithOptions(opts *UpdateOptions) error {
+	return SelfUpdateContext(context.Background(), opts)
+}
+
+// SelfUpdateContext downloads and installs the latest release, aborting when ctx is done
+func SelfUpdateContext(ctx context.Context, opts *UpdateOptions) error {
+	if opts == nil {
+		opts = &UpdateOptions{}
+	}
+
+	var release *Release
+	err := withRetry(ctx, opts.MaxAttempts, func() (err error) {
+		release, err = selectRelease(ctx, opts.Channel, opts.Prerelease)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("failed to get latest release: %w", err)
 	}
 
-	assetName := getAssetName()
-	var downloadURL string
+	return installRelease(ctx, release, opts)
+}
 
-	for _, asset := range release.Assets {
-		if asset.Name == assetName {
-			downloadURL = asset.BrowserDownloadURL
-			break
-		}
-	}
+// SelfUpdateToVersion installs the release with the given tag, allowing downgrades
+func SelfUpdateToVersion(tag string) error {
+	return SelfUpdateToVersionWithOptions(tag, nil)
+}
 
-	if downloadURL == "" {
-		return fmt.Errorf("no suitable binary found for %s/%s", runtime.GOOS, runtime.GOARCH)
+// SelfUpdateToVersionWithOptions installs the release with the given tag using opts
+func SelfUpdateToVersionWithOptions(tag string, opts *UpdateOptions) error {
+	return SelfUpdateToVersionContext(context.Background(), tag, opts)
+}
+
+// SelfUpdateToVersionContext installs the release with the given tag, aborting when ctx is done
+func SelfUpdateToVersionContext(ctx context.Context, tag string, opts *UpdateOptions) error {
+	if opts == nil {
+		opts = &UpdateOptions{}
 	}
 
-	// Download the archive
-	resp, err := http.Get(downloadURL)
+	var release *Release
+	err := withRetry(ctx, opts.MaxAttempts, func() (err error) {
+		release, err = getReleaseByTag(ctx, tag)
+		return err
+	})
 	if err != nil {
-		return fmt.Errorf("failed to download update: %w", err)
+		return err
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to download update: HTTP %d", resp.StatusCode)
+	return installRelease(ctx, release, opts)
+}
+
+// installRelease downloads, verifies and installs the binary for the current platform from release
+func installRelease(ctx context.Context, release *Release, opts *UpdateOptions) error {
+	asset := findAsset(release)
+	assetName, downloadURL := asset.Name, asset.BrowserDownloadURL
+	if downloadURL == "" {
+		return fmt.Errorf("no suitable binary found for %s/%s (expected %s)", runtime.GOOS, runtime.GOARCH, getAssetName())
+	}
+
+	var expectedChecksum string
+	err := withRetry(ctx, opts.MaxAttempts, func() (err error) {
+		expectedChecksum, err = findChecksum(ctx, release, assetName)
+		return err
+	})
+	if err != nil {
+		return err
 	}
 
 	// Get current executable path
@@ -89,62 +295,411 @@ func SelfUpdate() error {
 		return fmt.Errorf("failed to get executable path: %w", err)
 	}
 
-	// Create temp file in the same directory as executable to avoid cross-device link error
+	// Create temp files in the same directory as executable to avoid cross-device link error
 	execDir := filepath.Dir(execPath)
-	tmpFile, err := os.CreateTemp(execDir, "vibe-skills-update-*")
+
+	// Spool the archive to disk so extraction never holds it in memory
+	archiveFile, err := createTempFile(execDir, "vibe-skills-download-*")
+	if err != nil {
+		return err
+	}
+	archivePath := archiveFile.Name()
+	defer func() { _ = os.Remove(archivePath) }()
+
+	err = withRetry(ctx, opts.MaxAttempts, func() error {
+		// Start over from an empty file on every attempt
+		if err := archiveFile.Truncate(0); err != nil {
+			return err
+		}
+		if _, err := archiveFile.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		return downloadToFile(ctx, downloadURL, archiveFile, asset.Size, opts.Progress)
+	})
+	_ = archiveFile.Close()
 	if err != nil {
-		// Fallback to system temp if same directory fails
-		tmpFile, err = os.CreateTemp("", "vibe-skills-update-*")
+		return fmt.Errorf("failed to download update: %w", err)
+	}
+
+	// Verify integrity before touching the current executable
+	if err := verifyFileChecksum(archivePath, expectedChecksum); err != nil {
+		return fmt.Errorf("%s: %w", assetName, err)
+	}
+
+	if opts.VerifySignature {
+		archive, err := os.ReadFile(archivePath)
 		if err != nil {
-			return fmt.Errorf("failed to create temp file: %w", err)
+			return fmt.Errorf("failed to read downloaded archive: %w", err)
+		}
+		if err := verifyReleaseSignature(ctx, release, assetName, archive, opts.PublicKey); err != nil {
+			return err
 		}
 	}
+
+	tmpFile, err := createTempFile(execDir, "vibe-skills-update-*")
+	if err != nil {
+		return err
+	}
 	tmpPath := tmpFile.Name()
 	defer func() { _ = os.Remove(tmpPath) }()
 
-	// Extract binary from archive
-	var binaryData []byte
+	// Extract binary from archive straight into the temp file
+	binaryName := "vibe-skills"
 	if runtime.GOOS == "windows" {
-		binaryData, err = extractFromZip(resp.Body, "vibe-skills.exe")
-	} else {
-		binaryData, err = extractFromTarGz(resp.Body, "vibe-skills")
+		binaryName += ".exe"
+	}
+	err = extractBinary(archivePath, assetName, binaryName, tmpFile)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
 	}
 	if err != nil {
 		return fmt.Errorf("failed to extract binary: %w", err)
 	}
 
-	// Write extracted binary to temp file
-	if _, err := tmpFile.Write(binaryData); err != nil {
-		return fmt.Errorf("failed to write update: %w", err)
+	if err := validateExecutable(tmpPath); err != nil {
+		return fmt.Errorf("downloaded binary is invalid: %w", err)
 	}
-	_ = tmpFile.Close()
 
 	// Make executable
 	if err := os.Chmod(tmpPath, 0755); err != nil {
 		return fmt.Errorf("failed to chmod: %w", err)
 	}
 
-	// Replace current executable
-	// Try rename first (faster, same filesystem)
-	if err := os.Rename(tmpPath, execPath); err != nil {
-		// Fallback to copy if rename fails (cross-device link)
-		if err := copyFile(tmpPath, execPath); err != nil {
-			return fmt.Errorf("failed to replace executable: %w", err)
+	if err := replaceExecutable(tmpPath, execPath); err != nil {
+		return err
+	}
+
+	// Not fatal: the update is in place, and Gatekeeper only objects if the
+	// binary was quarantined
+	if err := clearQuarantine(execPath); err != nil {
+		logging.Verbosef("failed to clear quarantine attribute on %s: %v", execPath, err)
+	}
+	return nil
+}
+
+// replaceExecutable swaps execPath for the binary at newPath, keeping a backup
+// of the current executable and restoring it if the replacement fails. The
+// backup stays after a successful replace so RollbackUpdate can restore it;
+// the next update overwrites it.
+func replaceExecutable(newPath, execPath string) error {
+	backupPath := execPath + backupSuffix
+	if err := copyFile(execPath, backupPath); err != nil {
+		return fmt.Errorf("failed to back up current executable: %w", err)
+	}
+
+	if err := installExecutable(newPath, execPath); err != nil {
+		if restoreErr := installExecutable(backupPath, execPath); restoreErr != nil {
+			return fmt.Errorf("failed to replace executable: %w (restore from %s failed: %v)", err, backupPath, restoreErr)
+		}
+		_ = os.Remove(backupPath)
+		return fmt.Errorf("failed to replace executable: %w", err)
+	}
+	return nil
+}
+
+// CleanupOldExecutable removes the executable left behind by an update on
+// Windows, which could not be deleted while it was running. It does nothing
+// if there is none.
+func CleanupOldExecutable() error {
+	execPath, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	err = os.Remove(execPath + oldSuffix)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// RollbackUpdate restores the executable replaced by the last successful
+// update, and removes the backup
+func RollbackUpdate() error {
+	execPath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to get executable path: %w", err)
+	}
+
+	backupPath := execPath + backupSuffix
+	if _, err := os.Stat(backupPath); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no backup found at %s", backupPath)
+		}
+		return fmt.Errorf("failed to check backup: %w", err)
+	}
+
+	if err := installExecutable(backupPath, execPath); err != nil {
+		return fmt.Errorf("failed to restore executable: %w", err)
+	}
+	_ = os.Remove(backupPath)
+
+	return nil
+}
+
+// findChecksum returns the expected SHA256 digest for assetName, looking for a
+// per-asset .sha256 file first and falling back to a shared checksums.txt
+func findChecksum(ctx context.Context, release *Release, assetName string) (string, error) {
+	var sumURL, manifestURL string
+	for _, asset := range release.Assets {
+		switch asset.Name {
+		case assetName + ".sha256":
+			sumURL = asset.BrowserDownloadURL
+		case checksumsFileName:
+			manifestURL = asset.BrowserDownloadURL
+		}
+	}
+
+	if sumURL != "" {
+		data, err := download(ctx, sumURL)
+		if err != nil {
+			return "", fmt.Errorf("failed to download checksum: %w", err)
+		}
+		fields := strings.Fields(string(data))
+		if len(fields) == 0 {
+			return "", fmt.Errorf("checksum file for %s is empty", assetName)
+		}
+		return fields[0], nil
+	}
+
+	if manifestURL != "" {
+		data, err := download(ctx, manifestURL)
+		if err != nil {
+			return "", fmt.Errorf("failed to download checksums: %w", err)
+		}
+		if sum, ok := parseChecksumsFile(data)[assetName]; ok {
+			return sum, nil
+		}
+		return "", fmt.Errorf("no checksum found for %s in %s", assetName, checksumsFileName)
+	}
+
+	return "", fmt.Errorf("no checksum published for %s", assetName)
+}
+
+// parseChecksumsFile parses a "<hash>  <filename>" manifest such as GoReleaser's
+// checksums.txt into a map of filename to hex digest. Blank lines and # comments
+// are skipped, and the "*" binary-mode marker before filenames is ignored.
+func parseChecksumsFile(data []byte) map[string]string {
+	sums := make(map[string]string)
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		sums[strings.TrimPrefix(fields[1], "*")] = fields[0]
+	}
+	return sums
+}
+
+// verifyFileChecksum compares the SHA256 digest of the file at path against the expected hex digest
+func verifyFileChecksum(path, expected string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	return verifyChecksum(f, expected)
+}
+
+// verifyChecksum compares the SHA256 digest of r against the expected hex digest
+func verifyChecksum(r io.Reader, expected string) error {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return fmt.Errorf("failed to compute checksum: %w", err)
+	}
+	actual := hex.EncodeToString(h.Sum(nil))
+	if !strings.EqualFold(actual, strings.TrimSpace(expected)) {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", expected, actual)
+	}
+	return nil
+}
+
+// verifyReleaseSignature downloads the detached signature for assetName and
+// checks it against data. A missing signature asset is treated as a failure.
+func verifyReleaseSignature(ctx context.Context, release *Release, assetName string, data, publicKey []byte) error {
+	if len(publicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid public key: expected %d bytes, got %d", ed25519.PublicKeySize, len(publicKey))
+	}
+
+	var sigURL string
+	for _, asset := range release.Assets {
+		if asset.Name == assetName+".sig" {
+			sigURL = asset.BrowserDownloadURL
+			break
+		}
+	}
+	if sigURL == "" {
+		return fmt.Errorf("no signature published for %s", assetName)
+	}
+
+	sigData, err := download(ctx, sigURL)
+	if err != nil {
+		return fmt.Errorf("failed to download signature: %w", err)
+	}
+
+	if err := verifySignature(data, sigData, publicKey); err != nil {
+		return fmt.Errorf("%s: %w", assetName, err)
+	}
+	return nil
+}
+
+// verifySignature checks an Ed25519 signature, either raw or base64-encoded, over data
+func verifySignature(data, sig, publicKey []byte) error {
+	if len(sig) != ed25519.SignatureSize {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig)))
+		if err != nil {
+			return fmt.Errorf("failed to decode signature: %w", err)
 		}
+		sig = decoded
+	}
+
+	if !ed25519.Verify(ed25519.PublicKey(publicKey), data, sig) {
+		return fmt.Errorf("signature verification failed")
+	}
+	return nil
+}
+
+// download fetches url and returns the response body
+func download(ctx context.Context, url string) ([]byte, error) {
+	resp, err := get(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return io.ReadAll(resp.Body)
+}
+
+// downloadToFile streams the body of url into f, reporting progress if set.
+// A size above zero is checked against the bytes received.
+func downloadToFile(ctx context.Context, url string, f *os.File, size int64, progress ProgressFunc) error {
+	resp, err := get(ctx, url)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var body io.Reader = resp.Body
+	if progress != nil {
+		body = io.TeeReader(resp.Body, &progressWriter{total: resp.ContentLength, report: progress})
+	}
+
+	n, err := io.Copy(f, body)
+	if err != nil {
+		// A connection dropped mid-body is worth another attempt
+		return retryable(ctx, err)
 	}
 
+	// Catch truncated downloads and substituted error pages before extraction
+	if size > 0 && n != size {
+		err := fmt.Errorf("downloaded %d bytes, expected %d", n, size)
+		if n < size {
+			return retryable(ctx, err)
+		}
+		return err
+	}
 	return nil
 }
 
-// extractFromTarGz extracts a specific file from a tar.gz archive
-func extractFromTarGz(r io.Reader, filename string) ([]byte, error) {
+// progressWriter counts bytes written through it and forwards the running total
+type progressWriter struct {
+	downloaded int64
+	total      int64
+	report     ProgressFunc
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	p.downloaded += int64(len(b))
+	p.report(p.downloaded, p.total)
+	return len(b), nil
+}
+
+// get performs a GET request and returns the response if it succeeded with HTTP 200
+func get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.Verbosef("GET %s", url)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, retryable(ctx, err)
+	}
+	logging.Debugf("%s: %s", url, resp.Status)
+
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		err := fmt.Errorf("HTTP %d", resp.StatusCode)
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return nil, &retryableError{err: err}
+		}
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// createTempFile creates a temp file in dir, falling back to the system temp directory
+func createTempFile(dir, pattern string) (*os.File, error) {
+	f, err := os.CreateTemp(dir, pattern)
+	if err != nil {
+		f, err = os.CreateTemp("", pattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temp file: %w", err)
+		}
+	}
+	return f, nil
+}
+
+// extractBinary extracts filename from the archive at path into w, choosing the
+// decompression format from the extension of assetName
+func extractBinary(path, assetName, filename string, w io.Writer) error {
+	if strings.HasSuffix(assetName, ".zip") {
+		return extractFromZip(path, filename, w)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	switch {
+	case strings.HasSuffix(assetName, ".tar.gz"), strings.HasSuffix(assetName, ".tgz"):
+		return extractFromTarGz(f, filename, w)
+	case strings.HasSuffix(assetName, ".tar.xz"):
+		xzr, err := xz.NewReader(f)
+		if err != nil {
+			return fmt.Errorf("failed to create xz reader: %w", err)
+		}
+		return extractFromTar(xzr, filename, w)
+	case strings.HasSuffix(assetName, ".tar.bz2"):
+		return extractFromTar(bzip2.NewReader(f), filename, w)
+	default:
+		return fmt.Errorf("unsupported archive format: %s", assetName)
+	}
+}
+
+// extractFromTarGz extracts a specific file from a tar.gz stream into w
+func extractFromTarGz(r io.Reader, filename string, w io.Writer) error {
 	gzr, err := gzip.NewReader(r)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create gzip reader: %w", err)
+		return fmt.Errorf("failed to create gzip reader: %w", err)
 	}
 	defer func() { _ = gzr.Close() }()
 
-	tr := tar.NewReader(gzr)
+	return extractFromTar(gzr, filename, w)
+}
+
+// extractFromTar extracts a specific file from an uncompressed tar stream into w
+func extractFromTar(r io.Reader, filename string, w io.Writer) error {
+	tr := tar.NewReader(r)
 
 	for {
 		header, err := tr.Next()
@@ -152,52 +707,45 @@ func extractFromTarGz(r io.Reader, filename string) ([]byte, error) {
 			break
 		}
 		if err != nil {
-			return nil, fmt.Errorf("failed to read tar: %w", err)
+			return fmt.Errorf("failed to read tar: %w", err)
 		}
 
 		// Check if this is the file we're looking for
 		if header.Typeflag == tar.TypeReg && filepath.Base(header.Name) == filename {
-			data, err := io.ReadAll(tr)
-			if err != nil {
-				return nil, fmt.Errorf("failed to read file from tar: %w", err)
+			if _, err := io.Copy(w, tr); err != nil {
+				return fmt.Errorf("failed to read file from tar: %w", err)
 			}
-			return data, nil
+			return nil
 		}
 	}
 
-	return nil, fmt.Errorf("file %s not found in archive", filename)
+	return fmt.Errorf("file %s not found in archive", filename)
 }
 
-// extractFromZip extracts a specific file from a zip archive
-func extractFromZip(r io.Reader, filename string) ([]byte, error) {
-	// We need to read the entire zip into memory since zip requires random access
-	data, err := io.ReadAll(r)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read zip data: %w", err)
-	}
-
-	zipReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+// extractFromZip extracts a specific file from the zip archive at path into w
+func extractFromZip(path, filename string, w io.Writer) error {
+	zipReader, err := zip.OpenReader(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create zip reader: %w", err)
+		return fmt.Errorf("failed to open zip: %w", err)
 	}
+	defer func() { _ = zipReader.Close() }()
 
 	for _, file := range zipReader.File {
 		if filepath.Base(file.Name) == filename {
 			rc, err := file.Open()
 			if err != nil {
-				return nil, fmt.Errorf("failed to open file in zip: %w", err)
+				return fmt.Errorf("failed to open file in zip: %w", err)
 			}
 			defer func() { _ = rc.Close() }()
 
-			fileData, err := io.ReadAll(rc)
-			if err != nil {
-				return nil, fmt.Errorf("failed to read file from zip: %w", err)
+			if _, err := io.Copy(w, rc); err != nil {
+				return fmt.Errorf("failed to read file from zip: %w", err)
 			}
-			return fileData, nil
+			return nil
 		}
 	}
 
-	return nil, fmt.Errorf("file %s not found in archive", filename)
+	return fmt.Errorf("file %s not found in archive", filename)
 }
 
 // copyFile copies a file from src to dst
@@ -229,39 +777,411 @@ func copyFile(src, dst string) error {
 	return destFile.Sync()
 }
 
-func getLatestRelease() (*Release, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/latest", repoOwner, repoName)
+var errReleaseNotFound = errors.New("release not found")
 
-	resp, err := http.Get(url)
-	if err != nil {
+// getLatestRelease returns the newest release published on channel
+func getLatestRelease(ctx context.Context, channel string) (*Release, error) {
+	if channel == "" || channel == ChannelStable {
+		return getRelease(ctx, "releases/latest")
+	}
+	if channel != ChannelBeta && channel != ChannelNightly {
+		return nil, fmt.Errorf("unknown release channel: %s (expected %s, %s or %s)", channel, ChannelStable, ChannelBeta, ChannelNightly)
+	}
+
+	// GitHub lists releases newest first
+	var releases []Release
+	if err := getJSON(ctx, "releases", &releases); err != nil {
 		return nil, err
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get release info: HTTP %d", resp.StatusCode)
+	for i := range releases {
+		if matchesChannel(&releases[i], channel) {
+			return &releases[i], nil
+		}
 	}
+	return nil, fmt.Errorf("no releases found on %s channel", channel)
+}
 
-	var release Release
-	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+// selectRelease returns the release to update to: the highest published
+// version when prerelease is set, else the newest on channel
+func selectRelease(ctx context.Context, channel string, prerelease bool) (*Release, error) {
+	if !prerelease {
+		return getLatestRelease(ctx, channel)
+	}
+	return getHighestRelease(ctx)
+}
+
+// getHighestRelease returns the published release, prerelease or not, with
+// the highest version. Tags that are not semver rank below all others, in the
+// newest first order GitHub lists them in.
+func getHighestRelease(ctx context.Context) (*Release, error) {
+	var releases []Release
+	if err := getJSON(ctx, "releases", &releases); err != nil {
+		return nil, err
+	}
+
+	var best, first *Release
+	var bestVersion *version.Semver
+	for i := range releases {
+		if releases[i].Draft {
+			continue
+		}
+		if first == nil {
+			first = &releases[i]
+		}
+		v, err := version.ParseSemver(releases[i].TagName)
+		if err == nil && (bestVersion == nil || v.Compare(bestVersion) > 0) {
+			best, bestVersion = &releases[i], v
+		}
+	}
+	if best == nil {
+		best = first
+	}
+	if best == nil {
+		return nil, fmt.Errorf("no published releases found")
+	}
+	return best, nil
+}
+
+// matchesChannel reports whether release belongs to channel. Each channel also
+// includes the more stable ones, so beta users still receive stable releases.
+func matchesChannel(release *Release, channel string) bool {
+	if release.Draft {
+		return false
+	}
+	if !release.Prerelease {
+		return true
+	}
+
+	switch channel {
+	case ChannelNightly:
+		return true
+	case ChannelBeta:
+		return !strings.Contains(strings.ToLower(release.TagName), ChannelNightly)
+	default:
+		return false
+	}
+}
+
+// getReleaseByTag fetches the release for tag, accepting tags with or without the "v" prefix
+func getReleaseByTag(ctx context.Context, tag string) (*Release, error) {
+	candidates := []string{tag}
+	if !strings.HasPrefix(tag, "v") {
+		candidates = append(candidates, "v"+tag)
+	}
+
+	for _, candidate := range candidates {
+		release, err := getRelease(ctx, "releases/tags/"+candidate)
+		if err == nil {
+			return release, nil
+		}
+		if !errors.Is(err, errReleaseNotFound) {
+			return nil, err
+		}
+	}
+
+	tags, err := listReleaseTags(ctx)
+	if err != nil || len(tags) == 0 {
+		return nil, fmt.Errorf("release %s not found", tag)
+	}
+	return nil, fmt.Errorf("release %s not found, available versions: %s", tag, strings.Join(tags, ", "))
+}
+
+// listReleaseTags returns the tag names of published releases, newest first
+func listReleaseTags(ctx context.Context) ([]string, error) {
+	var releases []Release
+	if err := getJSON(ctx, "releases", &releases); err != nil {
 		return nil, err
 	}
 
+	tags := make([]string, 0, len(releases))
+	for _, r := range releases {
+		tags = append(tags, r.TagName)
+	}
+	return tags, nil
+}
+
+func getRelease(ctx context.Context, path string) (*Release, error) {
+	var release Release
+	if err := getJSON(ctx, path, &release); err != nil {
+		return nil, err
+	}
 	return &release, nil
 }
 
-func getAssetName() string {
-	os := runtime.GOOS
-	arch := runtime.GOARCH
+// getJSON fetches a GitHub API path under the repository and decodes the response into v
+func getJSON(ctx context.Context, path string, v interface{}) error {
+	url := fmt.Sprintf("%s/repos/%s/%s/%s", source.APIBaseURL, source.Owner, source.Repo, path)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if token := githubToken(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return retryable(ctx, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errReleaseNotFound
+	}
+
+	if isRateLimited(resp) {
+		return fmt.Errorf("GitHub API rate limit exceeded: set GITHUB_TOKEN or GH_TOKEN to authenticate")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		err := fmt.Errorf("failed to get release info: HTTP %d", resp.StatusCode)
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return &retryableError{err: err}
+		}
+		return err
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// retryableError marks failures worth retrying, such as dropped connections and 5xx responses
+type retryableError struct {
+	err error
+}
+
+func (e *retryableError) Error() string { return e.err.Error() }
+func (e *retryableError) Unwrap() error { return e.err }
+
+// retryable wraps a transport error as retryable unless ctx was cancelled
+func retryable(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return err
+	}
+	return &retryableError{err: err}
+}
+
+// withRetry calls fn up to attempts times with exponential backoff, retrying
+// only errors marked as retryable
+func withRetry(ctx context.Context, attempts int, fn func() error) error {
+	if attempts <= 0 {
+		attempts = defaultMaxAttempts
+	}
+
+	delay := initialRetryDelay
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		var re *retryableError
+		if err == nil || attempt >= attempts || !errors.As(err, &re) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// githubToken returns a GitHub token from the environment, if any
+func githubToken() string {
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		return token
+	}
+	return os.Getenv("GH_TOKEN")
+}
+
+// isRateLimited reports whether resp is a GitHub rate-limit rejection
+func isRateLimited(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return false
+	}
+	return resp.Header.Get("X-RateLimit-Remaining") == "0" || resp.Header.Get("Retry-After") != ""
+}
+
+// archiveExtensions lists supported release archive formats in order of preference
+var archiveExtensions = []string{"tar.gz", "tar.xz", "tar.bz2", "zip", "tgz"}
+
+// findAsset returns the release archive for the current platform, preferring
+// the default format for the OS. The zero Asset means there is none.
+func findAsset(release *Release) Asset {
+	return matchAsset(release.Assets, runtime.GOOS, runtime.GOARCH, goarm(), isMusl())
+}
+
+// matchAsset picks the archive for a platform: an exact match of the names
+// the release is expected to use, or else the asset naming the project, OS
+// and architecture in any order, preferring a musl build when libc is musl
+func matchAsset(assets []Asset, goos, goarch, arm string, musl bool) Asset {
+	byName := make(map[string]Asset, len(assets))
+	for _, asset := range assets {
+		byName[asset.Name] = asset
+	}
+	for _, name := range assetNames(goos, goarch, arm, musl) {
+		if asset, ok := byName[name]; ok {
+			return asset
+		}
+	}
+
+	best, bestScore := Asset{}, 0
+	for _, asset := range assets {
+		if score := assetScore(asset.Name, goos, goarch, arm, musl); score > bestScore {
+			best, bestScore = asset, score
+		}
+	}
+	return best
+}
+
+// assetNames lists the exact archive names to look for, best first
+func assetNames(goos, goarch, arm string, musl bool) []string {
+	exts := preferredExtensions(goos)
+
+	var names []string
+	for _, arch := range archAliases(goarch, arm) {
+		base := fmt.Sprintf("%s_%s_%s", source.ProjectName, goos, arch)
+		bases := []string{base}
+		if musl {
+			bases = []string{base + "_musl", fmt.Sprintf("%s_%s-musl_%s", source.ProjectName, goos, arch), base}
+		}
+		for _, b := range bases {
+			for _, ext := range exts {
+				names = append(names, b+"."+ext)
+			}
+		}
+	}
+	return names
+}
+
+// preferredExtensions orders archiveExtensions for goos: zip first on Windows,
+// where it is the default format, and as listed elsewhere
+func preferredExtensions(goos string) []string {
+	if goos != "windows" {
+		return archiveExtensions
+	}
+	exts := []string{"zip"}
+	for _, ext := range archiveExtensions {
+		if ext != "zip" {
+			exts = append(exts, ext)
+		}
+	}
+	return exts
+}
+
+// archAliases returns the names release archives use for goarch, the one
+// GoReleaser produces first
+func archAliases(goarch, arm string) []string {
+	switch goarch {
+	case "amd64":
+		return []string{"x86_64", "amd64", "x64"}
+	case "arm64":
+		return []string{"arm64", "aarch64"}
+	case "386":
+		return []string{"i386", "386", "i686", "x86"}
+	case "arm":
+		if arm != "" {
+			return []string{"armv" + arm, "arm", "armhf"}
+		}
+		return []string{"arm", "armv7", "armv6", "armhf"}
+	}
+	return []string{goarch}
+}
+
+// osAliases returns the names release archives use for goos
+func osAliases(goos string) []string {
+	switch goos {
+	case "darwin":
+		return []string{"darwin", "macos", "osx"}
+	case "windows":
+		return []string{"windows", "win"}
+	}
+	return []string{goos}
+}
+
+// assetScore rates how well an asset name fits the platform, 0 meaning it is
+// not a supported archive for it. Names are compared token by token, so arm
+// never matches arm64.
+func assetScore(name, goos, goarch, arm string, musl bool) int {
+	lower := strings.ToLower(name)
+	supported := false
+	for _, ext := range archiveExtensions {
+		if strings.HasSuffix(lower, "."+ext) {
+			lower = strings.TrimSuffix(lower, "."+ext)
+			supported = true
+			break
+		}
+	}
+	if !supported || !strings.HasPrefix(lower, strings.ToLower(source.ProjectName)) {
+		return 0
+	}
+
+	tokens := make(map[string]bool)
+	fields := strings.FieldsFunc(lower, func(r rune) bool { return r == '_' || r == '-' || r == '.' })
+	for idx, token := range fields {
+		tokens[token] = true
+		// x86_64 is split at its underscore like any other separator, and is
+		// not the x86 of 386 builds
+		if idx > 0 && fields[idx-1] == "x86" && token == "64" {
+			tokens["x86_64"] = true
+			delete(tokens, "x86")
+		}
+	}
+	if !hasAnyToken(tokens, osAliases(goos)) {
+		return 0
+	}
+
+	aliases := archAliases(goarch, arm)
+	score := 0
+	for idx, alias := range aliases {
+		if tokens[alias] {
+			score = 10 * (len(aliases) - idx)
+			break
+		}
+	}
+	if score == 0 {
+		return 0
+	}
 
-	if arch == "amd64" {
-		arch = "x86_64"
+	if tokens["musl"] == musl {
+		score += 5
 	}
+	return score
+}
+
+func hasAnyToken(tokens map[string]bool, names []string) bool {
+	for _, name := range names {
+		if tokens[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// goarm returns the ARM version the binary was built for, if any
+func goarm() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "GOARM" {
+				return strings.SplitN(setting.Value, ",", 2)[0]
+			}
+		}
+	}
+	return ""
+}
 
-	ext := "tar.gz"
-	if os == "windows" {
-		ext = "zip"
+// isMusl reports whether this is a Linux system whose libc is musl, as on Alpine
+func isMusl() bool {
+	if runtime.GOOS != "linux" {
+		return false
 	}
+	matches, _ := filepath.Glob("/lib/ld-musl-*.so.1")
+	return len(matches) > 0
+}
 
-	return fmt.Sprintf("vibe-skills_%s_%s.%s", os, arch, ext)
+// getAssetName returns the name of the archive a release is expected to
+// provide for the current platform
+func getAssetName() string {
+	return assetNames(runtime.GOOS, runtime.GOARCH, goarm(), false)[0]
 }
@@ -0,0 +1,133 @@
+package updater
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"testing"
+)
+
+func TestAssetNamesNoDuplicates(t *testing.T) {
+	for _, goos := range []string{"linux", "darwin", "windows"} {
+		t.Run(goos, func(t *testing.T) {
+			names := assetNames(goos, "amd64", "", false)
+			seen := make(map[string]bool, len(names))
+			for _, name := range names {
+				if seen[name] {
+					t.Errorf("assetNames lists %s twice", name)
+				}
+				seen[name] = true
+			}
+		})
+	}
+
+	if got, want := assetNames("windows", "amd64", "", false)[0], "vibe-skills_windows_x86_64.zip"; got != want {
+		t.Errorf("first Windows asset name = %s, want %s", got, want)
+	}
+	if got, want := assetNames("linux", "amd64", "", false)[0], "vibe-skills_linux_x86_64.tar.gz"; got != want {
+		t.Errorf("first Linux asset name = %s, want %s", got, want)
+	}
+}
+
+func TestMatchAsset(t *testing.T) {
+	assets := []Asset{
+		{Name: "vibe-skills_linux_x86_64.tar.gz"},
+		{Name: "vibe-skills_linux_x86_64_musl.tar.gz"},
+		{Name: "vibe-skills_linux_arm64.tar.gz"},
+		{Name: "vibe-skills_linux_armv7.tar.gz"},
+		{Name: "vibe-skills_darwin_arm64.tar.gz"},
+		{Name: "vibe-skills_windows_x86_64.tar.gz"},
+		{Name: "vibe-skills_windows_x86_64.zip"},
+		{Name: "vibe-skills_linux_x86_64.tar.gz.sig"},
+		{Name: "checksums.txt"},
+	}
+
+	tests := []struct {
+		goos, goarch, arm string
+		musl              bool
+		want              string
+	}{
+		{"linux", "amd64", "", false, "vibe-skills_linux_x86_64.tar.gz"},
+		{"linux", "amd64", "", true, "vibe-skills_linux_x86_64_musl.tar.gz"},
+		{"linux", "arm64", "", false, "vibe-skills_linux_arm64.tar.gz"},
+		{"linux", "arm", "7", false, "vibe-skills_linux_armv7.tar.gz"},
+		{"darwin", "arm64", "", false, "vibe-skills_darwin_arm64.tar.gz"},
+		{"windows", "amd64", "", false, "vibe-skills_windows_x86_64.zip"},
+		{"darwin", "amd64", "", false, ""},
+		{"freebsd", "amd64", "", false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.goos+"/"+tt.goarch+tt.arm, func(t *testing.T) {
+			if got := matchAsset(assets, tt.goos, tt.goarch, tt.arm, tt.musl); got.Name != tt.want {
+				t.Errorf("matchAsset = %q, want %q", got.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchAssetLooseNames(t *testing.T) {
+	tests := []struct {
+		name   string
+		assets []string
+		goos   string
+		goarch string
+		want   string
+	}{
+		{"other order", []string{"vibe-skills-x86_64-linux.tar.gz"}, "linux", "amd64", "vibe-skills-x86_64-linux.tar.gz"},
+		{"os alias", []string{"vibe-skills-macos-arm64.zip"}, "darwin", "arm64", "vibe-skills-macos-arm64.zip"},
+		{"386 is not x86_64", []string{"vibe-skills-linux-x86_64.tar.gz"}, "linux", "386", ""},
+		{"x86 for 386", []string{"vibe-skills-linux-x86.tar.gz"}, "linux", "386", "vibe-skills-linux-x86.tar.gz"},
+		{"arm is not arm64", []string{"vibe-skills-linux-arm64.tar.gz"}, "linux", "arm", ""},
+		{"unsupported archive", []string{"vibe-skills-linux-amd64.deb"}, "linux", "amd64", ""},
+		{"other project", []string{"other-linux-amd64.tar.gz"}, "linux", "amd64", ""},
+		{"preferred alias", []string{"vibe-skills-linux-x64.tar.gz", "vibe-skills-linux-amd64.tar.gz"}, "linux", "amd64", "vibe-skills-linux-amd64.tar.gz"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var assets []Asset
+			for _, name := range tt.assets {
+				assets = append(assets, Asset{Name: name})
+			}
+			if got := matchAsset(assets, tt.goos, tt.goarch, "", false); got.Name != tt.want {
+				t.Errorf("matchAsset(%v) = %q, want %q", tt.assets, got.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("release archive")
+	sig := ed25519.Sign(privateKey, data)
+	encoded := []byte(base64.StdEncoding.EncodeToString(sig) + "\n")
+
+	tests := []struct {
+		name    string
+		data    []byte
+		sig     []byte
+		key     ed25519.PublicKey
+		wantErr bool
+	}{
+		{"raw", data, sig, publicKey, false},
+		{"base64", data, encoded, publicKey, false},
+		{"tampered data", []byte("release archivE"), sig, publicKey, true},
+		{"other key", data, sig, otherKey, true},
+		{"not base64", data, []byte("not a signature!"), publicKey, true},
+		{"truncated", data, []byte(base64.StdEncoding.EncodeToString(sig[:32])), publicKey, true},
+		{"empty", data, nil, publicKey, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifySignature(tt.data, tt.sig, tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("verifySignature error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Semver is a parsed semantic version (https://semver.org)
+type Semver struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease []string
+	Build      string
+}
+
+// ParseSemver parses versions like "1.2.3", "v1.2.3-rc.1" or "1.2.3+build.5".
+// Missing minor or patch components default to zero.
+func ParseSemver(s string) (*Semver, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if s == "" {
+		return nil, fmt.Errorf("invalid version: empty")
+	}
+
+	var v Semver
+	if idx := strings.Index(s, "+"); idx >= 0 {
+		v.Build = s[idx+1:]
+		s = s[:idx]
+	}
+	if idx := strings.Index(s, "-"); idx >= 0 {
+		if idx == len(s)-1 {
+			return nil, fmt.Errorf("invalid version: empty pre-release in %q", s)
+		}
+		v.Prerelease = strings.Split(s[idx+1:], ".")
+		for _, id := range v.Prerelease {
+			if id == "" {
+				return nil, fmt.Errorf("invalid version: empty pre-release identifier in %q", s)
+			}
+		}
+		s = s[:idx]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return nil, fmt.Errorf("invalid version: %q", s)
+	}
+
+	nums := [3]int{}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid version: %q", s)
+		}
+		nums[i] = n
+	}
+	v.Major, v.Minor, v.Patch = nums[0], nums[1], nums[2]
+
+	return &v, nil
+}
+
+// Compare returns -1, 0 or 1 depending on whether v is lower than, equal to,
+// or greater than other. Build metadata is ignored as required by the spec.
+func (v *Semver) Compare(other *Semver) int {
+	if c := compareInt(v.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Patch, other.Patch); c != 0 {
+		return c
+	}
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+// String formats the version without a "v" prefix
+func (v *Semver) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Prerelease) > 0 {
+		s += "-" + strings.Join(v.Prerelease, ".")
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// Compare parses and compares two version strings
+func Compare(a, b string) (int, error) {
+	va, err := ParseSemver(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := ParseSemver(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.Compare(vb), nil
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// comparePrerelease orders pre-release identifiers; a version without a
+// pre-release has higher precedence than one with (1.2.0-rc1 < 1.2.0)
+func comparePrerelease(a, b []string) int {
+	if len(a) == 0 || len(b) == 0 {
+		return -compareInt(len(a), len(b))
+	}
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		aNum, bNum := isNumeric(a[i]), isNumeric(b[i])
+		switch {
+		case aNum && bNum:
+			if c := compareNumeric(a[i], b[i]); c != 0 {
+				return c
+			}
+		case aNum:
+			// Numeric identifiers sort before alphanumeric ones
+			return -1
+		case bNum:
+			return 1
+		default:
+			if c := strings.Compare(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return compareInt(len(a), len(b))
+}
+
+// isNumeric reports whether a pre-release identifier is made of digits only;
+// "-1" or "+1" are alphanumeric identifiers, unlike for strconv.Atoi
+func isNumeric(id string) bool {
+	for _, c := range id {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return id != ""
+}
+
+// compareNumeric compares two numeric identifiers of any length
+func compareNumeric(a, b string) int {
+	a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	if c := compareInt(len(a), len(b)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
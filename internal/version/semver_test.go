@@ -0,0 +1,102 @@
+package version
+
+import "testing"
+
+func TestComparePrecedence(t *testing.T) {
+	// The ordering example of SemVer 2.0.0 §11, lowest first
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"2.0.0",
+	}
+
+	for i, a := range ordered {
+		for j, b := range ordered {
+			want := compareInt(i, j)
+			got, err := Compare(a, b)
+			if err != nil {
+				t.Fatalf("Compare(%q, %q): %v", a, b, err)
+			}
+			if got != want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", a, b, got, want)
+			}
+		}
+	}
+}
+
+func TestCompareIgnoresBuildMetadata(t *testing.T) {
+	tests := [][2]string{
+		{"1.0.0+build.1", "1.0.0+build.2"},
+		{"1.0.0", "1.0.0+20130313144700"},
+		{"1.0.0-beta+exp.sha.5114f85", "1.0.0-beta"},
+		{"v1.2.3", "1.2.3"},
+	}
+	for _, tt := range tests {
+		got, err := Compare(tt[0], tt[1])
+		if err != nil {
+			t.Fatalf("Compare(%q, %q): %v", tt[0], tt[1], err)
+		}
+		if got != 0 {
+			t.Errorf("Compare(%q, %q) = %d, want 0", tt[0], tt[1], got)
+		}
+	}
+}
+
+func TestComparePrereleaseIdentifiers(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.0-2", "1.0.0-10", -1},
+		{"1.0.0-99999999999999999999", "1.0.0-100000000000000000000", -1},
+		{"1.0.0-1", "1.0.0--1", -1},
+	}
+	for _, tt := range tests {
+		got, err := Compare(tt.a, tt.b)
+		if err != nil {
+			t.Fatalf("Compare(%q, %q): %v", tt.a, tt.b, err)
+		}
+		if got != tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestParseSemver(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"1.2.3", "1.2.3", false},
+		{"v1.2.3-rc.1+build.5", "1.2.3-rc.1+build.5", false},
+		{"1.2", "1.2.0", false},
+		{"", "", true},
+		{"1.2.3.4", "", true},
+		{"1.x.3", "", true},
+		{"1.0.0-", "", true},
+		{"1.0.0-a..b", "", true},
+		{"1.0.0-a.", "", true},
+		{"1.0.0-.a", "", true},
+		{"1.0.0-+build", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			v, err := ParseSemver(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSemver(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if err == nil && v.String() != tt.want {
+				t.Errorf("ParseSemver(%q) = %q, want %q", tt.in, v.String(), tt.want)
+			}
+		})
+	}
+}
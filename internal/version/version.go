@@ -1,11 +1,23 @@
 package version
 
+import "runtime"
+
 var (
 	Version = "dev"
 	Commit  = "none"
 	Date    = "unknown"
 )
 
+// Info is the build metadata of the running binary
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	Date      string `json:"date"`
+	GoVersion string `json:"go_version"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+}
+
 func GetVersion() string {
 	return Version
 }
@@ -13,3 +25,15 @@ func GetVersion() string {
 func GetFullVersion() string {
 	return Version + " (" + Commit + ") built at " + Date
 }
+
+// GetInfo returns the build metadata, including the Go toolchain and platform
+func GetInfo() Info {
+	return Info{
+		Version:   Version,
+		Commit:    Commit,
+		Date:      Date,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+	}
+}
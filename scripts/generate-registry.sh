@@ -28,6 +28,9 @@ for skill_file in $(find "$SKILLS_DIR" -name "SKILL.md" -type f | sort); do
   name=$(echo "$relative_path" | cut -d'/' -f2)
   path="${relative_path%/SKILL.md}/SKILL.md"
 
+  dependencies_json=""
+  tags_json=""
+
   # Check if file has YAML frontmatter (starts with ---)
   if head -1 "$skill_file" | grep -q '^---$'; then
     # Extract frontmatter content between first and second ---
@@ -47,6 +50,30 @@ for skill_file in $(find "$SKILLS_DIR" -name "SKILL.md" -type f | sort); do
       # Fallback: first non-header, non-empty line after frontmatter
       description=$(sed -n '/^---$/,/^---$/d; /^#/d; /^$/d; /^\`\`\`/d; p' "$skill_file" | head -1)
     fi
+
+    # Extract dependencies from frontmatter: "dependencies: a, b" or "dependencies: [a, b]"
+    fm_deps=$(echo "$frontmatter" | grep '^dependencies:' | sed 's/^dependencies:[[:space:]]*//; s/^\[//; s/\]$//')
+    if [ -n "$fm_deps" ]; then
+      for dep in $(echo "$fm_deps" | tr ',' ' '); do
+        if [ -z "$dependencies_json" ]; then
+          dependencies_json="\"$dep\""
+        else
+          dependencies_json="$dependencies_json, \"$dep\""
+        fi
+      done
+    fi
+
+    # Extract tags from frontmatter: "tags: a, b" or "tags: [a, b]"
+    fm_tags=$(echo "$frontmatter" | grep '^tags:' | sed 's/^tags:[[:space:]]*//; s/^\[//; s/\]$//')
+    if [ -n "$fm_tags" ]; then
+      for tag in $(echo "$fm_tags" | tr ',' ' '); do
+        if [ -z "$tags_json" ]; then
+          tags_json="\"$tag\""
+        else
+          tags_json="$tags_json, \"$tag\""
+        fi
+      done
+    fi
   else
     # No frontmatter: extract description from first non-empty, non-header line
     description=$(grep -v '^#' "$skill_file" | grep -v '^$' | grep -v '^\`\`\`' | head -1)
@@ -58,9 +85,17 @@ for skill_file in $(find "$SKILLS_DIR" -name "SKILL.md" -type f | sort); do
   # Find additional files in skill directory (excluding SKILL.md and hidden files)
   skill_dir=$(dirname "$skill_file")
   additional_files=""
+  executable_files=""
   while IFS= read -r -d '' file; do
     # Get relative path from skill directory
     rel_path="${file#$skill_dir/}"
+    if [ -x "$file" ]; then
+      if [ -z "$executable_files" ]; then
+        executable_files="\"$rel_path\""
+      else
+        executable_files="$executable_files, \"$rel_path\""
+      fi
+    fi
     if [ "$rel_path" != "SKILL.md" ]; then
       if [ -z "$additional_files" ]; then
         additional_files="\"$rel_path\""
@@ -70,6 +105,9 @@ for skill_file in $(find "$SKILLS_DIR" -name "SKILL.md" -type f | sort); do
     fi
   done < <(find "$skill_dir" -type f ! -name ".*" ! -name ".DS_Store" -print0 2>/dev/null | sort -z)
 
+  # Last commit touching the skill, if the skills are in a git repository
+  updated_at=$(git -C "$skill_dir" log -1 --format=%cI -- . 2>/dev/null || true)
+
   # Build files array
   if [ -n "$additional_files" ]; then
     files_json="[\"SKILL.md\", $additional_files]"
@@ -89,7 +127,20 @@ for skill_file in $(find "$SKILLS_DIR" -name "SKILL.md" -type f | sort); do
   printf '      "stack": "%s",\n' "$stack" >> "$OUTPUT_FILE"
   printf '      "description": "%s",\n' "$description" >> "$OUTPUT_FILE"
   printf '      "path": "%s",\n' "$path" >> "$OUTPUT_FILE"
-  printf '      "files": %s\n' "$files_json" >> "$OUTPUT_FILE"
+  printf '      "files": %s' "$files_json" >> "$OUTPUT_FILE"
+  if [ -n "$executable_files" ]; then
+    printf ',\n      "executables": [%s]' "$executable_files" >> "$OUTPUT_FILE"
+  fi
+  if [ -n "$dependencies_json" ]; then
+    printf ',\n      "dependencies": [%s]' "$dependencies_json" >> "$OUTPUT_FILE"
+  fi
+  if [ -n "$tags_json" ]; then
+    printf ',\n      "tags": [%s]' "$tags_json" >> "$OUTPUT_FILE"
+  fi
+  if [ -n "$updated_at" ]; then
+    printf ',\n      "updated_at": "%s"' "$updated_at" >> "$OUTPUT_FILE"
+  fi
+  printf '\n' >> "$OUTPUT_FILE"
   printf '    }' >> "$OUTPUT_FILE"
 
   skill_count=$((skill_count + 1))
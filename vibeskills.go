@@ -0,0 +1,249 @@
+// Package vibeskills installs and updates vibe-skills from Go programs,
+// without going through the command line.
+//
+//	client, err := vibeskills.New(vibeskills.Options{Dir: projectDir})
+//	if err != nil {
+//		return err
+//	}
+//	results, err := client.Install(ctx, "code-reviewer", "commit-convention")
+//
+// The supported surface is what this package exports: Options, Client and its
+// methods, CheckForUpdate, and the result types and errors aliased below.
+// They follow semantic versioning with the CLI releases. Everything under
+// internal/ may change at any time.
+package vibeskills
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cuongtl1992/vibe-skills/internal/installer"
+	"github.com/cuongtl1992/vibe-skills/internal/registry"
+	"github.com/cuongtl1992/vibe-skills/internal/updater"
+)
+
+// Types returned by Client
+type (
+	Skill          = registry.Skill
+	InstallResult  = installer.InstallResult
+	InstallStatus  = installer.InstallStatus
+	InstalledSkill = installer.InstalledSkill
+	OutdatedSkill  = installer.OutdatedSkill
+	Scope          = installer.Scope
+	UpdateCheck    = updater.UpdateCheck
+)
+
+// Install outcomes, see InstallResult
+const (
+	StatusInstalled = installer.StatusInstalled
+	StatusSkipped   = installer.StatusSkipped
+	StatusFailed    = installer.StatusFailed
+)
+
+// Install scopes, see InstalledSkill
+const (
+	ScopeProject = installer.ScopeProject
+	ScopeGlobal  = installer.ScopeGlobal
+)
+
+// Error classes returned by Client, for use with errors.Is
+var (
+	ErrSkillNotFound   = installer.ErrSkillNotFound
+	ErrNotInstalled    = installer.ErrNotInstalled
+	ErrNetwork         = installer.ErrNetwork
+	ErrConflict        = installer.ErrConflict
+	ErrLocallyModified = installer.ErrLocallyModified
+)
+
+// Options configures a Client. The zero value installs from the public
+// registry's main branch into .claude/skills of the current directory.
+type Options struct {
+	Dir    string // Project directory; defaults to the current directory
+	Target string // Skills directory relative to Dir; defaults to .claude/skills
+	Global bool   // Install into ~/.claude/skills instead of the project
+
+	Owner   string   // Registry repository owner; defaults to the public registry
+	Repo    string   // Registry repository name
+	Ref     string   // Branch, tag or commit to read skills from; defaults to main
+	Token   string   // Token for a private registry; defaults to VIBE_SKILLS_TOKEN
+	Mirrors []string // Base URLs tried in order when GitHub cannot be reached
+
+	CacheTTL time.Duration // How long the cached index is used; defaults to one hour
+	NoCache  bool          // Always fetch from the registry, bypassing the cache
+	Offline  bool          // Only use the cache, never contacting GitHub
+
+	Force       bool // Overwrite existing skills on install and locally modified ones on update
+	MaxParallel int  // Skills installed at once; defaults to the installer's limit
+}
+
+// Client installs, updates and removes skills of one project
+type Client struct {
+	reg   *registry.GitHubRegistry
+	inst  *installer.Installer
+	force bool
+}
+
+// New creates a client from opts. Configuration files are not read; callers
+// pass every setting they need in opts.
+func New(opts Options) (*Client, error) {
+	if opts.Ref != "" {
+		if err := registry.ValidateRef(opts.Ref); err != nil {
+			return nil, err
+		}
+	}
+
+	dir := opts.Dir
+	if dir == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current directory: %w", err)
+		}
+		dir = cwd
+	}
+
+	token := opts.Token
+	if token == "" {
+		token = os.Getenv(registry.RegistryTokenEnv)
+	}
+	ttl := opts.CacheTTL
+	if ttl == 0 {
+		ttl = registry.DefaultCacheTTL
+	}
+
+	reg := registry.NewGitHubRegistry(&registry.GitHubRegistryOptions{
+		Owner:   opts.Owner,
+		Repo:    opts.Repo,
+		Ref:     opts.Ref,
+		Token:   token,
+		Mirrors: opts.Mirrors,
+		Cache:   registry.NewCacheWithTTL(ttl),
+		NoCache: opts.NoCache,
+		Offline: opts.Offline,
+	})
+
+	inst := installer.NewWithTarget(reg, dir, opts.Target)
+	if opts.Global {
+		inst.SetScope(installer.ScopeGlobal)
+	}
+	inst.SetForce(opts.Force)
+	if opts.MaxParallel > 0 {
+		inst.SetMaxParallel(opts.MaxParallel)
+	}
+
+	return &Client{reg: reg, inst: inst, force: opts.Force}, nil
+}
+
+// SkillsDir returns the directory skills are installed into
+func (c *Client) SkillsDir() string {
+	return c.inst.SkillsDir()
+}
+
+// Ref returns the registry ref skills are read from
+func (c *Client) Ref() string {
+	return c.reg.GetRef()
+}
+
+// List returns the skills available in the registry
+func (c *Client) List() ([]Skill, error) {
+	return c.reg.List()
+}
+
+// Search returns the registry skills matching query, most relevant first
+func (c *Client) Search(query string) ([]Skill, error) {
+	return c.reg.Search(query)
+}
+
+// Install installs the named skills and their dependencies, returning one
+// result per skill. The error joins the failures, if any.
+func (c *Client) Install(ctx context.Context, names ...string) ([]InstallResult, error) {
+	results := c.inst.InstallMultipleResultsContext(ctx, names)
+	return results, resultsErr(results)
+}
+
+// InstallStacks installs every skill of the given stacks
+func (c *Client) InstallStacks(ctx context.Context, stacks ...string) ([]InstallResult, error) {
+	results, err := c.inst.InstallStacksResultsContext(ctx, stacks)
+	if err != nil {
+		return nil, err
+	}
+	return results, resultsErr(results)
+}
+
+// UpdateReport sorts the skills an update looked at by outcome
+type UpdateReport struct {
+	Updated []string // Reinstalled with the registry's newer files
+	Current []string // Already up to date
+	Skipped []string // Modified locally and left alone; set Options.Force to overwrite
+}
+
+// Update updates the named skills, or every installed skill when no names
+// are given. The error joins the failures, if any; skills not started when
+// ctx is done fail with its error.
+func (c *Client) Update(ctx context.Context, names ...string) (*UpdateReport, error) {
+	report := &UpdateReport{}
+	if len(names) == 0 {
+		var errs []error
+		report.Updated, report.Current, report.Skipped, errs = c.inst.UpdateAllContext(ctx, c.force)
+		return report, errors.Join(errs...)
+	}
+
+	var errs []error
+	for _, name := range names {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: not started: %w", name, err))
+			continue
+		}
+
+		err := c.inst.Update(name, c.force)
+		switch {
+		case errors.Is(err, installer.ErrUpToDate):
+			report.Current = append(report.Current, name)
+		case errors.Is(err, installer.ErrLocallyModified):
+			report.Skipped = append(report.Skipped, name)
+		case err != nil:
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		default:
+			report.Updated = append(report.Updated, name)
+		}
+	}
+	return report, errors.Join(errs...)
+}
+
+// Remove uninstalls the named skills
+func (c *Client) Remove(names ...string) error {
+	_, errs := c.inst.RemoveMultiple(names)
+	return errors.Join(errs...)
+}
+
+// Installed describes the skills installed in the project and globally
+func (c *Client) Installed() ([]InstalledSkill, error) {
+	return c.inst.ListInstalledDetailed()
+}
+
+// Outdated returns the installed skills whose registry files changed since
+// they were installed. The error joins the skills that could not be checked.
+func (c *Client) Outdated() ([]OutdatedSkill, error) {
+	outdated, errs := c.inst.Outdated()
+	return outdated, errors.Join(errs...)
+}
+
+// CheckForUpdate reports the newest vibe-skills release and whether it is
+// newer than this build, including prereleases when prerelease is set. It
+// never replaces any executable.
+func CheckForUpdate(ctx context.Context, prerelease bool) (*UpdateCheck, error) {
+	return updater.CheckForUpdateWithOptions(ctx, &updater.CheckOptions{Prerelease: prerelease})
+}
+
+// resultsErr joins the errors of the failed results
+func resultsErr(results []InstallResult) error {
+	var errs []error
+	for _, result := range results {
+		if result.Status == installer.StatusFailed {
+			errs = append(errs, fmt.Errorf("%s: %w", result.Name, result.Err))
+		}
+	}
+	return errors.Join(errs...)
+}